@@ -1,8 +1,17 @@
 package card
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// errors returned when parsing cards
+var (
+	ErrInvalidCard   = errors.New("invalid card")
+	ErrDuplicateCard = errors.New("duplicate card")
 )
 
 // CardSuit represents an enum of card suits
@@ -88,3 +97,85 @@ func ParseMultiPokerCardString(s string) []Card {
 	}
 	return result
 }
+
+// suitSymbols maps the unicode suit symbols to their suits
+var suitSymbols = map[rune]CardSuit{
+	'♣': CLUBS, '♧': CLUBS,
+	'♦': DIAMONDS, '♢': DIAMONDS,
+	'♥': HEARTS, '♡': HEARTS,
+	'♠': SPADES, '♤': SPADES,
+}
+
+// parseCardPrefix parses the card at the start of s and returns it with the number of bytes it used
+func parseCardPrefix(s string) (Card, int, error) {
+	if len(s) == 0 {
+		return EMPTY, 0, fmt.Errorf("%w: missing face", ErrInvalidCard)
+	}
+
+	n := 1
+	var face CardFace
+	if strings.HasPrefix(s, "10") {
+		face = TEN
+		n = 2
+	} else if fi := strings.IndexByte("23456789tjqka", byte(unicode.ToLower(rune(s[0])))); fi >= 0 && s[0] < utf8.RuneSelf {
+		face = CardFace(fi) + TWO
+	} else {
+		r, _ := utf8.DecodeRuneInString(s)
+		return EMPTY, 0, fmt.Errorf("%w: unknown face %q", ErrInvalidCard, r)
+	}
+
+	r, rn := utf8.DecodeRuneInString(s[n:])
+	if rn == 0 {
+		return EMPTY, 0, fmt.Errorf("%w: %q is missing a suit", ErrInvalidCard, s[:n])
+	}
+	suit, ok := suitSymbols[r]
+	if !ok {
+		si := strings.IndexRune("cdhs", unicode.ToLower(r))
+		if si < 0 {
+			return EMPTY, 0, fmt.Errorf("%w: unknown suit %q in %q", ErrInvalidCard, r, s[:n+rn])
+		}
+		suit = CardSuit(si)
+	}
+
+	return CreateCard(face, suit), n + rn, nil
+}
+
+// ParseCard parses a single card such as "ah", "Td", "10c", or "Q♠", surrounding whitespace is ignored,
+// faces and suits may be upper or lower case and tens may be written as "10" or "t"
+func ParseCard(s string) (Card, error) {
+	s = strings.TrimSpace(s)
+	c, n, err := parseCardPrefix(s)
+	if err != nil {
+		return EMPTY, err
+	}
+	if n != len(s) {
+		return EMPTY, fmt.Errorf("%w: unexpected %q after %q", ErrInvalidCard, s[n:], s[:n])
+	}
+	return c, nil
+}
+
+// ParseCards parses a list of cards such as "AhKd", "Ah Kd", or "a♠, 10♥",
+// the cards may be separated by whitespace or commas, and a card may not be listed twice
+func ParseCards(s string) ([]Card, error) {
+	result := make([]Card, 0, len(s)>>1)
+	seen := map[Card]bool{}
+	for i := 0; i < len(s); {
+		r, rn := utf8.DecodeRuneInString(s[i:])
+		if unicode.IsSpace(r) || r == ',' {
+			i += rn
+			continue
+		}
+
+		c, n, err := parseCardPrefix(s[i:])
+		if err != nil {
+			return nil, fmt.Errorf("card at offset %d: %w", i, err)
+		}
+		if seen[c] {
+			return nil, fmt.Errorf("%w: %s at offset %d", ErrDuplicateCard, c, i)
+		}
+		seen[c] = true
+		result = append(result, c)
+		i += n
+	}
+	return result, nil
+}
@@ -58,3 +58,115 @@ func TestSortingCards(t *testing.T) {
 		assert.Equal(t, cards[ci], ocards[ci].String())
 	}
 }
+
+func TestParseCard(t *testing.T) {
+	tcs := []struct {
+		s string
+		c Card
+	}{
+		{"2c", CreateCard(TWO, CLUBS)},
+		{"7H", CreateCard(SEVEN, HEARTS)},
+		{"As", CreateCard(ACE, SPADES)},
+		{"td", CreateCard(TEN, DIAMONDS)},
+		{"T♦", CreateCard(TEN, DIAMONDS)},
+		{"10c", CreateCard(TEN, CLUBS)},
+		{"q♠", CreateCard(QUEEN, SPADES)},
+		{"K♥", CreateCard(KING, HEARTS)},
+		{"j♣", CreateCard(JACK, CLUBS)},
+		{" 9s ", CreateCard(NINE, SPADES)},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.s, func(tt *testing.T) {
+			c, err := ParseCard(tc.s)
+			assert.NoError(tt, err)
+			assert.Equal(tt, tc.c, c)
+		})
+	}
+}
+
+func TestParseCardErrors(t *testing.T) {
+	for _, s := range []string{"", "A", "1x", "1", "10", "zs", "ax", "ahk", "a☃", "♠a"} {
+		t.Run(s, func(tt *testing.T) {
+			_, err := ParseCard(s)
+			assert.ErrorIs(tt, err, ErrInvalidCard)
+		})
+	}
+}
+
+func TestParseCards(t *testing.T) {
+	tcs := []struct {
+		s  string
+		cs []Card
+	}{
+		{"", []Card{}},
+		{"2sTs4c", []Card{CreateCard(TWO, SPADES), CreateCard(TEN, SPADES), CreateCard(FOUR, CLUBS)}},
+		{"Ah Kd", []Card{CreateCard(ACE, HEARTS), CreateCard(KING, DIAMONDS)}},
+		{"a♠, 10♥,\tQC", []Card{CreateCard(ACE, SPADES), CreateCard(TEN, HEARTS), CreateCard(QUEEN, CLUBS)}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.s, func(tt *testing.T) {
+			cs, err := ParseCards(tc.s)
+			assert.NoError(tt, err)
+			assert.Equal(tt, tc.cs, cs)
+		})
+	}
+
+	_, err := ParseCards("ahkda")
+	assert.ErrorIs(t, err, ErrInvalidCard, "a trailing face without a suit is an error")
+
+	_, err = ParseCards("ahkd;qc")
+	assert.ErrorIs(t, err, ErrInvalidCard)
+
+	_, err = ParseCards("ahkdAH")
+	assert.ErrorIs(t, err, ErrDuplicateCard)
+}
+
+func FuzzParseCard(f *testing.F) {
+	for _, s := range []string{"2c", "10d", "Th", "a♠", "1x", "A", ""} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		c, err := ParseCard(s)
+		if err != nil {
+			return
+		}
+
+		rc, err := ParseCard(c.String())
+		if err != nil || rc != c {
+			t.Errorf("%q parsed as %s, which did not round trip: %v %v", s, c, rc, err)
+		}
+	})
+}
+
+func FuzzParseCards(f *testing.F) {
+	for _, s := range []string{"2sts4c2hac", "Ah Kd", "a♠, 10♥", "ahah", "ahk"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		cs, err := ParseCards(s)
+		if err != nil {
+			return
+		}
+
+		joined := ""
+		for _, c := range cs {
+			joined += c.String()
+		}
+		rcs, err := ParseCards(joined)
+		if err != nil {
+			t.Fatalf("%q parsed as %s, which did not parse again: %v", s, joined, err)
+		}
+		if len(rcs) != len(cs) {
+			t.Fatalf("%q parsed as %s, which did not round trip", s, joined)
+		}
+		for ci := range cs {
+			if rcs[ci] != cs[ci] {
+				t.Fatalf("%q parsed as %s, which did not round trip", s, joined)
+			}
+		}
+	})
+}
@@ -0,0 +1,127 @@
+// Command headsupgen generates the exact heads-up preflop equity table that is embedded in the headsup package.
+//
+// Every board of every canonical matchup is enumerated, which takes a few hours on a single cpu.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/deck"
+	"github.com/aaron-jencks/poker/hand"
+	"github.com/aaron-jencks/poker/headsup"
+)
+
+// matchupPoints returns two points for every board won by the first hand of the matchup and one for every split board.
+// The boards are visited with plain loops rather than simulation.EnumerateTableHand,
+// which roughly halves the time it takes to build the table.
+func matchupPoints(m headsup.Matchup) (uint32, error) {
+	remaining := deck.CreateStackedDeck(deck.StandardCards())
+	if err := remaining.RemoveCards(m[0][0], m[0][1], m[1][0], m[1][1]); err != nil {
+		return 0, err
+	}
+	cards := remaining.Cards
+
+	var hero, villain [7]card.Card
+	copy(hero[:], m[0][:])
+	copy(villain[:], m[1][:])
+
+	var points, boards uint32
+	for ci0 := 0; ci0 < len(cards); ci0++ {
+		hero[2], villain[2] = cards[ci0], cards[ci0]
+		for ci1 := ci0 + 1; ci1 < len(cards); ci1++ {
+			hero[3], villain[3] = cards[ci1], cards[ci1]
+			for ci2 := ci1 + 1; ci2 < len(cards); ci2++ {
+				hero[4], villain[4] = cards[ci2], cards[ci2]
+				for ci3 := ci2 + 1; ci3 < len(cards); ci3++ {
+					hero[5], villain[5] = cards[ci3], cards[ci3]
+					for ci4 := ci3 + 1; ci4 < len(cards); ci4++ {
+						hero[6], villain[6] = cards[ci4], cards[ci4]
+						switch hand.Evaluate(hero[:]).Compare(hand.Evaluate(villain[:])) {
+						case 1:
+							points += 2
+						case 0:
+							points++
+						}
+						boards++
+					}
+				}
+			}
+		}
+	}
+	if boards != headsup.Boards {
+		return 0, fmt.Errorf("enumerated %d boards, expected %d", boards, headsup.Boards)
+	}
+	return points, nil
+}
+
+func main() {
+	output := flag.String("o", "equity.bin", "the file to write the table to")
+	workers := flag.Int("workers", 0, "the number of goroutines to enumerate with, all cpus are used if this is less than 1")
+	flag.Parse()
+
+	if *workers < 1 {
+		*workers = runtime.NumCPU()
+	}
+
+	matchups := headsup.Matchups()
+	points := make([]uint32, len(matchups))
+	errs := make([]error, len(matchups))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	next, done := 0, 0
+	for w := 0; w < *workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				mi := next
+				next++
+				mu.Unlock()
+				if mi >= len(matchups) {
+					return
+				}
+
+				points[mi], errs[mi] = matchupPoints(matchups[mi])
+
+				mu.Lock()
+				done++
+				if done%1000 == 0 || done == len(matchups) {
+					fmt.Fprintf(os.Stderr, "%d/%d matchups\n", done, len(matchups))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	table := map[headsup.Matchup]uint32{}
+	for mi, m := range matchups {
+		if errs[mi] != nil {
+			fmt.Fprintf(os.Stderr, "failed to enumerate %s against %s: %v\n", m[0], m[1], errs[mi])
+			os.Exit(1)
+		}
+		table[m] = points[mi]
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+	if err := headsup.NewTable(table).Encode(f); err != nil {
+		f.Close()
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,110 @@
+// Command preflopgen generates the preflop equity table that is embedded in the preflop package.
+//
+// Every starting hand class is simulated against 1 to 9 random opponents. The equities against a single opponent
+// can be enumerated exactly with -exact, which is much slower, since every opponent hand and board is visited.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/deck"
+	"github.com/aaron-jencks/poker/hand"
+	"github.com/aaron-jencks/poker/preflop"
+	"github.com/aaron-jencks/poker/simulation"
+)
+
+// simulateClass returns the equity of the class against the given number of random opponents
+func simulateClass(class hand.PokerRange, opponents int, runner simulation.Runner) (float64, error) {
+	results, err := runner.Run(simulation.Table{
+		Players: opponents + 1,
+		Ranges:  map[int][]hand.PokerRange{0: {class}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return results[0].Equity(runner.Iterations), nil
+}
+
+// enumerateClass returns the exact equity of the class against a single random opponent.
+// Every combo of a class has the same equity against a random hand, so only the first combo is enumerated.
+func enumerateClass(class hand.PokerRange) (float64, error) {
+	hero := class.Pairs()[0]
+	remaining := []card.Card{}
+	for _, c := range deck.StandardCards() {
+		if c != hero[0] && c != hero[1] {
+			remaining = append(remaining, c)
+		}
+	}
+
+	total, count := 0.0, 0
+	for ci0 := range remaining {
+		for ci1 := ci0 + 1; ci1 < len(remaining); ci1++ {
+			equity, err := simulation.EnumerateTableHand(2, map[int][]card.Card{
+				0: hero,
+				1: {remaining[ci0], remaining[ci1]},
+			}, nil, nil)
+			if err != nil {
+				return 0, err
+			}
+			total += equity[0].Equity
+			count++
+		}
+	}
+	return total / float64(count), nil
+}
+
+func main() {
+	output := flag.String("o", "equity.json", "the file to write the table to")
+	seed := flag.Int64("seed", 1, "the seed of the simulations")
+	iterations := flag.Int("n", 200000, "the number of hands simulated for each class and number of opponents")
+	workers := flag.Int("workers", 0, "the number of goroutines to simulate with, all cpus are used if this is less than 1")
+	exact := flag.Bool("exact", false, "enumerate the equities against a single opponent exactly, this takes hours")
+	flag.Parse()
+
+	table := &preflop.EquityTable{
+		Version:      preflop.Version,
+		Seed:         *seed,
+		Iterations:   *iterations,
+		ExactHeadsUp: *exact,
+	}
+	runner := simulation.Runner{Seed: *seed, Workers: *workers, Iterations: *iterations}
+
+	for ci, class := range hand.EquityRanking {
+		ce := preflop.ClassEquity{Class: class.String()}
+		for opponents := 1; opponents <= preflop.MaxOpponents; opponents++ {
+			var equity float64
+			var err error
+			if opponents == 1 && *exact {
+				equity, err = enumerateClass(class)
+			} else {
+				equity, err = simulateClass(class, opponents, runner)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to compute %s against %d opponents: %v\n", class, opponents, err)
+				os.Exit(1)
+			}
+			ce.Equity = append(ce.Equity, math.Round(equity*1e6)/1e6)
+		}
+		table.Classes = append(table.Classes, ce)
+		fmt.Fprintf(os.Stderr, "%3d/%d %s\n", ci+1, len(hand.EquityRanking), class)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+	if err := table.Encode(f); err != nil {
+		f.Close()
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+}
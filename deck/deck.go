@@ -1,11 +1,19 @@
 package deck
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
 
 	"github.com/aaron-jencks/poker/card"
 )
 
+// errors returned when removing cards from a deck
+var (
+	ErrDuplicateCard = errors.New("card is used more than once")
+	ErrCardNotInDeck = errors.New("card is not in the deck")
+)
+
 // Deck represents a deck of cards
 type Deck struct {
 	Cards []card.Card
@@ -24,6 +32,15 @@ func (d *Deck) Shuffle() {
 	rand.Shuffle(d.Count(), func(i, j int) { d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i] })
 }
 
+// ShuffleWith shuffles the remaining cards in the deck using the given random source,
+// so that a deck can be shuffled repeatably or without sharing the global source
+func (d *Deck) ShuffleWith(r *rand.Rand) {
+	if d.Count() < 2 {
+		return
+	}
+	r.Shuffle(d.Count(), func(i, j int) { d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i] })
+}
+
 // Draw returns the top n cards of the deck and removes them from the deck
 func (d *Deck) Draw(n int) []card.Card {
 	if n == 0 || n >= d.Count() {
@@ -46,6 +63,40 @@ func (d *Deck) DrawCard(c card.Card) card.Card {
 	return card.EMPTY
 }
 
+// RemoveCards removes the given cards from the deck, such as burned, exposed, or mucked cards,
+// if a card is listed twice or isn't in the deck an error is returned and the deck is left untouched
+func (d *Deck) RemoveCards(cards ...card.Card) error {
+	remove := make(map[card.Card]bool, len(cards))
+	for _, c := range cards {
+		if remove[c] {
+			return fmt.Errorf("%w: %s", ErrDuplicateCard, c)
+		}
+		if !d.contains(c) {
+			return fmt.Errorf("%w: %s", ErrCardNotInDeck, c)
+		}
+		remove[c] = true
+	}
+
+	remaining := d.Cards[:0]
+	for _, dc := range d.Cards {
+		if !remove[dc] {
+			remaining = append(remaining, dc)
+		}
+	}
+	d.Cards = remaining
+	return nil
+}
+
+// contains returns true if the card is still in the deck
+func (d Deck) contains(c card.Card) bool {
+	for _, dc := range d.Cards {
+		if dc == c {
+			return true
+		}
+	}
+	return false
+}
+
 // CardFaceProbability returns the probability that f is the next card
 func (d *Deck) CardFaceProbability(f card.CardFace) float64 {
 	var count float64 = 0
@@ -75,20 +126,39 @@ func CreateEmptyDeck() Deck {
 	}
 }
 
-// CreateStandardDeck generates a deck with the standard 52 cards in it, plus a specified number of jokers
-func CreateStandardDeck() Deck {
-	d := Deck{
-		make([]card.Card, 0, 52),
-	}
+// StandardCards returns the standard 52 cards in order, by suit and then by face
+func StandardCards() []card.Card {
+	cards := make([]card.Card, 0, 52)
 	for s := card.CardSuit(0); s < card.SUITS; s++ {
 		for f := card.TWO; f < card.JOKER; f++ {
-			d.Cards = append(d.Cards, card.CreateCard(f, s))
+			cards = append(cards, card.CreateCard(f, s))
 		}
 	}
+	return cards
+}
+
+// CreateStandardDeck generates a deck with the standard 52 cards in it, plus a specified number of jokers
+func CreateStandardDeck() Deck {
+	d := Deck{
+		StandardCards(),
+	}
 	d.Shuffle()
 	return d
 }
 
+// CreateStandardDeckWithout generates a shuffled standard deck with the dead cards removed,
+// it returns an error if a dead card is listed twice or isn't a standard card
+func CreateStandardDeckWithout(dead []card.Card) (Deck, error) {
+	d := Deck{
+		StandardCards(),
+	}
+	if err := d.RemoveCards(dead...); err != nil {
+		return Deck{}, err
+	}
+	d.Shuffle()
+	return d, nil
+}
+
 // CreateStackedDeck generates a deck with a specific set of cards in it
 func CreateStackedDeck(cards []card.Card) Deck {
 	return Deck{
@@ -0,0 +1,41 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveCards(t *testing.T) {
+	d := CreateStandardDeck()
+	dead := card.ParseMultiPokerCardString("ah2c9d")
+	assert.NoError(t, d.RemoveCards(dead...))
+	assert.Equal(t, 49, d.Count())
+	for _, c := range dead {
+		assert.NotContains(t, d.Cards, c)
+	}
+
+	err := d.RemoveCards(card.ParseMultiPokerCardString("ksah")...)
+	assert.ErrorIs(t, err, ErrCardNotInDeck)
+	assert.Equal(t, 49, d.Count(), "a failed removal should leave the deck untouched")
+
+	err = d.RemoveCards(card.ParseMultiPokerCardString("ksks")...)
+	assert.ErrorIs(t, err, ErrDuplicateCard)
+	assert.Equal(t, 49, d.Count(), "a failed removal should leave the deck untouched")
+}
+
+func TestCreateStandardDeckWithout(t *testing.T) {
+	dead := card.ParseMultiPokerCardString("ahkh")
+	d, err := CreateStandardDeckWithout(dead)
+	assert.NoError(t, err)
+	assert.Equal(t, 50, d.Count())
+	assert.NotContains(t, d.Cards, dead[0])
+	assert.NotContains(t, d.Cards, dead[1])
+
+	_, err = CreateStandardDeckWithout([]card.Card{card.CreateCard(card.JOKER, card.SPADES)})
+	assert.ErrorIs(t, err, ErrCardNotInDeck)
+
+	_, err = CreateStandardDeckWithout(card.ParseMultiPokerCardString("ahah"))
+	assert.ErrorIs(t, err, ErrDuplicateCard)
+}
@@ -0,0 +1,57 @@
+package hand
+
+import (
+	"github.com/aaron-jencks/poker/card"
+)
+
+// Combo is a specific pair of hole cards,
+// canonical combos hold the higher card first so that each pair of cards has exactly one combo
+type Combo [2]card.Card
+
+// NewCombo returns the canonical combo of the two cards
+func NewCombo(c0, c1 card.Card) Combo {
+	if c0 < c1 {
+		return Combo{c1, c0}
+	}
+	return Combo{c0, c1}
+}
+
+// Contains returns true if either card of the combo is the given card
+func (c Combo) Contains(other card.Card) bool {
+	return c[0] == other || c[1] == other
+}
+
+// Class returns the starting hand class that the combo belongs to
+func (c Combo) Class() PokerRange {
+	return PokerRange{
+		F0:     c[0].Face(),
+		F1:     c[1].Face(),
+		Suited: c[0].Suit() == c[1].Suit(),
+	}
+}
+
+// String returns the combo in range notation, such as AhKh
+func (c Combo) String() string {
+	return string([]byte{
+		faceNotation(c[0].Face()), "cdhs"[c[0].Suit()],
+		faceNotation(c[1].Face()), "cdhs"[c[1].Suit()],
+	})
+}
+
+// classCombos returns the canonical combos of the class, 6 for pairs, 4 for suited hands, and 12 for offsuit hands
+func classCombos(r PokerRange) []Combo {
+	var combos []Combo
+	for s0 := card.CLUBS; s0 < card.SUITS; s0++ {
+		for s1 := card.CLUBS; s1 < card.SUITS; s1++ {
+			if r.F0 == r.F1 && s1 <= s0 {
+				// pairs are unordered, so only count each pair of suits once
+				continue
+			}
+			if (s0 == s1) != (r.Suited && r.F0 != r.F1) {
+				continue
+			}
+			combos = append(combos, NewCombo(card.CreateCard(r.F0, s0), card.CreateCard(r.F1, s1)))
+		}
+	}
+	return combos
+}
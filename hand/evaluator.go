@@ -0,0 +1,208 @@
+package hand
+
+import (
+	"math/bits"
+
+	"github.com/aaron-jencks/poker/card"
+)
+
+const faceCount = int(card.ACE-card.TWO) + 1 // number of distinct faces in a standard deck
+
+var (
+	// flushRanks holds the rank of the best flush for every 13-bit mask of faces in a single suit
+	flushRanks [1 << faceCount]HandRank
+	// noFlushRanks holds the rank of every multiset of 5-7 faces, indexed by hand size and then quinaryHash
+	noFlushRanks [8][]HandRank
+	// hashOffsets[f][r][c] is the amount added to the quinary hash when face f
+	// appears c times with r cards of the hand left to place
+	hashOffsets [faceCount][8][5]int
+)
+
+func init() {
+	// waysToFill[n][s] is the number of ways to place s cards on n faces, with at most 4 of each face
+	var waysToFill [faceCount + 1][8]int
+	waysToFill[0][0] = 1
+	for n := 1; n <= faceCount; n++ {
+		for s := 0; s < 8; s++ {
+			for c := 0; c <= 4 && c <= s; c++ {
+				waysToFill[n][s] += waysToFill[n-1][s-c]
+			}
+		}
+	}
+
+	for f := 0; f < faceCount; f++ {
+		for r := 0; r < 8; r++ {
+			for c := 1; c <= 4 && c <= r; c++ {
+				hashOffsets[f][r][c] = hashOffsets[f][r][c-1] + waysToFill[faceCount-1-f][r-c+1]
+			}
+		}
+	}
+
+	for m := range flushRanks {
+		if bits.OnesCount(uint(m)) >= 5 {
+			flushRanks[m] = rankFlush(uint16(m))
+		}
+	}
+
+	for n := 5; n <= 7; n++ {
+		noFlushRanks[n] = make([]HandRank, waysToFill[faceCount][n])
+		var counts [faceCount]byte
+		fillNoFlushRanks(counts[:], 0, n, n)
+	}
+}
+
+// fillNoFlushRanks recursively visits every way of placing the remaining cards
+// on the faces from f upwards and stores the rank of each complete hand
+func fillNoFlushRanks(counts []byte, f, remaining, n int) {
+	if f == faceCount-1 {
+		if remaining > 4 {
+			return
+		}
+		counts[f] = byte(remaining)
+		noFlushRanks[n][quinaryHash(counts, n)] = rankCounts(counts)
+		counts[f] = 0
+		return
+	}
+
+	for c := 0; c <= 4 && c <= remaining; c++ {
+		counts[f] = byte(c)
+		fillNoFlushRanks(counts, f+1, remaining-c, n)
+	}
+	counts[f] = 0
+}
+
+// quinaryHash returns the lexicographic index of the face counts among all
+// face counts that add up to n, which is a perfect hash into noFlushRanks
+func quinaryHash(counts []byte, n int) int {
+	h := 0
+	for f, c := range counts {
+		h += hashOffsets[f][n][c]
+		n -= int(c)
+	}
+	return h
+}
+
+// bestStraight returns the highest face of the best straight in the mask of faces,
+// or 0 if there is no straight
+func bestStraight(mask uint16) card.CardFace {
+	for top := faceCount - 1; top >= 4; top-- {
+		run := uint16(0x1f) << (top - 4)
+		if mask&run == run {
+			return card.CardFace(top) + card.TWO
+		}
+	}
+
+	// ace low
+	wheel := uint16(1<<(faceCount-1)) | 0xf
+	if mask&wheel == wheel {
+		return card.FIVE
+	}
+
+	return 0
+}
+
+// rankFlush returns the rank of the best hand made from a mask of at least five suited faces
+func rankFlush(mask uint16) HandRank {
+	if top := bestStraight(mask); top != 0 {
+		if top == card.ACE {
+			return newHandRank(ROYAL_FLUSH, top)
+		}
+		return newHandRank(STRAIGHT_FLUSH, top)
+	}
+
+	faces := make([]card.CardFace, 0, 5)
+	for f := faceCount - 1; f >= 0 && len(faces) < 5; f-- {
+		if mask&(1<<f) != 0 {
+			faces = append(faces, card.CardFace(f)+card.TWO)
+		}
+	}
+	return newHandRank(FLUSH, faces...)
+}
+
+// rankCounts returns the rank of the best hand made from the given number of each face,
+// assuming that no five of the cards share a suit
+func rankCounts(counts []byte) HandRank {
+	// highest returns the highest face that appears at least atLeast times, skipping the excluded faces
+	highest := func(atLeast byte, exclude ...card.CardFace) card.CardFace {
+	faces:
+		for f := faceCount - 1; f >= 0; f-- {
+			face := card.CardFace(f) + card.TWO
+			if counts[f] < atLeast {
+				continue
+			}
+			for _, e := range exclude {
+				if e == face {
+					continue faces
+				}
+			}
+			return face
+		}
+		return 0
+	}
+
+	// kickers returns the n highest faces not in the excluded faces
+	kickers := func(n int, exclude ...card.CardFace) []card.CardFace {
+		result := make([]card.CardFace, 0, len(exclude)+n)
+		result = append(result, exclude...)
+		for len(result)-len(exclude) < n {
+			result = append(result, highest(1, result...))
+		}
+		return result
+	}
+
+	if q := highest(4); q != 0 {
+		return newHandRank(FOUR_OF_A_KIND, kickers(1, q)...)
+	}
+
+	t := highest(3)
+	if t != 0 {
+		if p := highest(2, t); p != 0 {
+			return newHandRank(FULL_HOUSE, t, p)
+		}
+	}
+
+	var mask uint16
+	for f, c := range counts {
+		if c > 0 {
+			mask |= 1 << f
+		}
+	}
+	if top := bestStraight(mask); top != 0 {
+		return newHandRank(STRAIGHT, top)
+	}
+
+	if t != 0 {
+		return newHandRank(THREE_OF_A_KIND, kickers(2, t)...)
+	}
+
+	if p0 := highest(2); p0 != 0 {
+		if p1 := highest(2, p0); p1 != 0 {
+			return newHandRank(TWO_PAIR, kickers(1, p0, p1)...)
+		}
+		return newHandRank(PAIR, kickers(3, p0)...)
+	}
+
+	return newHandRank(HIGH_CARD, kickers(5)...)
+}
+
+// Evaluate returns the rank of the best five card hand that can be made from the given cards,
+// it uses precomputed tables and does not allocate, so it is much faster than FindHand.
+// Between 5 and 7 distinct cards must be given.
+func Evaluate(cards []card.Card) HandRank {
+	var suits [card.SUITS]uint16
+	var counts [faceCount]byte
+	for _, c := range cards {
+		f := c.Face() - card.TWO
+		suits[c.Suit()] |= 1 << f
+		counts[f]++
+	}
+
+	// with 7 or fewer cards a flush leaves too few cards for a full house or quads
+	for _, m := range suits {
+		if bits.OnesCount16(m) >= 5 {
+			return flushRanks[m]
+		}
+	}
+
+	return noFlushRanks[len(cards)][quinaryHash(counts[:], len(cards))]
+}
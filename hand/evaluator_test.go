@@ -0,0 +1,176 @@
+package hand
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/stretchr/testify/assert"
+)
+
+// standardCards returns the 52 cards of a standard deck in a fixed order
+func standardCards() []card.Card {
+	cards := make([]card.Card, 0, 52)
+	for s := card.CLUBS; s < card.SUITS; s++ {
+		for f := card.TWO; f < card.JOKER; f++ {
+			cards = append(cards, card.CreateCard(f, s))
+		}
+	}
+	return cards
+}
+
+// randomCards returns n distinct random cards
+func randomCards(rng *rand.Rand, n int) []card.Card {
+	cards := standardCards()
+	rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+	return cards[:n]
+}
+
+// bestSubsetRank returns the best rank out of every five card subset of the cards
+func bestSubsetRank(cards []card.Card) HandRank {
+	var best HandRank
+	for ci0 := 0; ci0 < len(cards)-4; ci0++ {
+		for ci1 := ci0 + 1; ci1 < len(cards)-3; ci1++ {
+			for ci2 := ci1 + 1; ci2 < len(cards)-2; ci2++ {
+				for ci3 := ci2 + 1; ci3 < len(cards)-1; ci3++ {
+					for ci4 := ci3 + 1; ci4 < len(cards); ci4++ {
+						r := Evaluate([]card.Card{cards[ci0], cards[ci1], cards[ci2], cards[ci3], cards[ci4]})
+						if r > best {
+							best = r
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+func TestEvaluateAllFiveCardHands(t *testing.T) {
+	expected := map[PokerHands]int{
+		HIGH_CARD:       1302540,
+		PAIR:            1098240,
+		TWO_PAIR:        123552,
+		THREE_OF_A_KIND: 54912,
+		STRAIGHT:        10200,
+		FLUSH:           5108,
+		FULL_HOUSE:      3744,
+		FOUR_OF_A_KIND:  624,
+		STRAIGHT_FLUSH:  36,
+		ROYAL_FLUSH:     4,
+	}
+
+	cards := standardCards()
+	counts := map[PokerHands]int{}
+	distinct := map[HandRank]bool{}
+	hcards := make([]card.Card, 5)
+	for ci0 := 0; ci0 < len(cards)-4; ci0++ {
+		for ci1 := ci0 + 1; ci1 < len(cards)-3; ci1++ {
+			for ci2 := ci1 + 1; ci2 < len(cards)-2; ci2++ {
+				for ci3 := ci2 + 1; ci3 < len(cards)-1; ci3++ {
+					for ci4 := ci3 + 1; ci4 < len(cards); ci4++ {
+						hcards[0], hcards[1], hcards[2], hcards[3], hcards[4] = cards[ci0], cards[ci1], cards[ci2], cards[ci3], cards[ci4]
+						r := Evaluate(hcards)
+						counts[r.Category()]++
+						distinct[r] = true
+					}
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, expected, counts, "expected the number of each kind of hand to match")
+	assert.Equal(t, 7462, len(distinct), "expected the number of distinct hand ranks to match")
+}
+
+func TestEvaluateMatchesFindHand(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		cards := randomCards(rng, 5)
+		r := Evaluate(cards)
+		h := FindHand(cards)
+		assert.Equal(t, h.Hand, r.Category(), "hand %s", h.String())
+	}
+}
+
+func TestEvaluateSixAndSevenCards(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 10000; i++ {
+		for _, n := range []int{6, 7} {
+			cards := randomCards(rng, n)
+			assert.Equal(t, bestSubsetRank(cards), Evaluate(cards), "cards %v", cards)
+		}
+	}
+}
+
+func TestEvaluateOrdering(t *testing.T) {
+	tcs := []struct {
+		name string
+		h1   string
+		h2   string
+	}{
+		{name: "wheel below six high straight", h1: "ac2h3d4h5s", h2: "2c3d4h5s6s"},
+		{name: "steel wheel below six high straight flush", h1: "as2s3s4s5s", h2: "2c3c4c5c6c"},
+		{name: "straight flush below royal flush", h1: "9stsjsqsks", h2: "tsjsqsksas"},
+		{name: "two pair fifth kicker", h1: "9c9dkhks2c", h2: "9h9skckd3c"},
+		{name: "two pair second pair", h1: "9c9dkhksac", h2: "tctdkcksad"},
+		{name: "full house pair", h1: "8h8d8ckhks", h2: "8s8c8dahac"},
+		{name: "flush last card", h1: "2c4c7ctcqc", h2: "3h4h7hthqh"},
+		{name: "trips kicker", h1: "5s5h5d2cjh", h2: "5c5h5d3cjh"},
+		{name: "pair below two pair", h1: "acadkhqsjh", h2: "2c2d3h3s4c"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(tt *testing.T) {
+			assert.Less(tt, Evaluate(card.ParseMultiPokerCardString(tc.h1)), Evaluate(card.ParseMultiPokerCardString(tc.h2)), "h1 should rank below h2")
+		})
+	}
+}
+
+func BenchmarkFindHand(b *testing.B) {
+	cards := randomCards(rand.New(rand.NewSource(3)), 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FindHand(cards)
+	}
+}
+
+func BenchmarkEvaluate5(b *testing.B) {
+	cards := randomCards(rand.New(rand.NewSource(3)), 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Evaluate(cards)
+	}
+}
+
+func BenchmarkFindHand7(b *testing.B) {
+	cards := randomCards(rand.New(rand.NewSource(3)), 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var best Hand
+		first := true
+		for skip0 := 0; skip0 < len(cards)-1; skip0++ {
+			for skip1 := skip0 + 1; skip1 < len(cards); skip1++ {
+				subset := make([]card.Card, 0, 5)
+				for ci, c := range cards {
+					if ci != skip0 && ci != skip1 {
+						subset = append(subset, c)
+					}
+				}
+				h := FindHand(subset)
+				if first || best.LessThan(h) {
+					best = h
+					first = false
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkEvaluate7(b *testing.B) {
+	cards := randomCards(rand.New(rand.NewSource(3)), 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Evaluate(cards)
+	}
+}
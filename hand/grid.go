@@ -0,0 +1,253 @@
+package hand
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/aaron-jencks/poker/card"
+)
+
+// totalCombos is the number of distinct hole card combos in a standard deck
+const totalCombos = 1326
+
+// RangeGrid is the 13x13 starting hand matrix, the rows and columns run from aces down to twos.
+// Pairs sit on the diagonal, suited hands above it, and offsuit hands below it.
+// Each cell holds the weight that the class is played with, in [0, 1].
+type RangeGrid [faceCount][faceCount]float64
+
+// gridIndex returns the row or column of the face in the grid
+func gridIndex(f card.CardFace) int {
+	return int(card.ACE - f)
+}
+
+// gridPosition returns the row and column of the class in the grid
+func gridPosition(r PokerRange) (int, int) {
+	hi, lo := gridIndex(r.F0), gridIndex(r.F1)
+	if hi > lo {
+		hi, lo = lo, hi
+	}
+	if r.Class() == OFFSUIT {
+		return lo, hi
+	}
+	return hi, lo
+}
+
+// Cell returns the weight of the cell, the class is suited when the row face is higher than the column face,
+// and offsuit when it is lower
+func (g RangeGrid) Cell(row, col card.CardFace) float64 {
+	return g[gridIndex(row)][gridIndex(col)]
+}
+
+// SetCell sets the weight of the cell, clamped to [0, 1]
+func (g *RangeGrid) SetCell(row, col card.CardFace, w float64) {
+	g[gridIndex(row)][gridIndex(col)] = math.Max(0, math.Min(1, w))
+}
+
+// ClassAt returns the starting hand class of the cell
+func (g RangeGrid) ClassAt(row, col card.CardFace) PokerRange {
+	if row == col {
+		return NewPairRange(row)
+	}
+	if row > col {
+		return NewSuitedRange(row, col)
+	}
+	return NewOffsuitRange(row, col)
+}
+
+// Weight returns the weight of the class
+func (g RangeGrid) Weight(r PokerRange) float64 {
+	row, col := gridPosition(r)
+	return g[row][col]
+}
+
+// SetWeight sets the weight of the class, clamped to [0, 1]
+func (g *RangeGrid) SetWeight(r PokerRange, w float64) {
+	row, col := gridPosition(r)
+	g[row][col] = math.Max(0, math.Min(1, w))
+}
+
+// Combos returns the number of combos in the grid, each scaled by its weight
+func (g RangeGrid) Combos() float64 {
+	total := 0.0
+	for row := card.ACE; row >= card.TWO; row-- {
+		for col := card.ACE; col >= card.TWO; col-- {
+			total += g.Cell(row, col) * float64(g.ClassAt(row, col).Combos())
+		}
+	}
+	return total
+}
+
+// Range returns the weighted combos of the grid, every combo of a class gets the weight of its cell
+func (g RangeGrid) Range() WeightedRange {
+	result := WeightedRange{}
+	for row := card.ACE; row >= card.TWO; row-- {
+		for col := card.ACE; col >= card.TWO; col-- {
+			w := g.Cell(row, col)
+			if w <= 0 {
+				continue
+			}
+			for _, c := range classCombos(g.ClassAt(row, col)) {
+				result[c] = w
+			}
+		}
+	}
+	return result
+}
+
+func (g RangeGrid) String() string {
+	return g.Range().String()
+}
+
+// Union returns the grid holding the larger weight of each cell
+func (g RangeGrid) Union(other RangeGrid) RangeGrid {
+	for row := range g {
+		for col := range g[row] {
+			g[row][col] = math.Max(g[row][col], other[row][col])
+		}
+	}
+	return g
+}
+
+// Intersect returns the grid holding the smaller weight of each cell
+func (g RangeGrid) Intersect(other RangeGrid) RangeGrid {
+	for row := range g {
+		for col := range g[row] {
+			g[row][col] = math.Min(g[row][col], other[row][col])
+		}
+	}
+	return g
+}
+
+// Subtract returns the grid with the weights of the other grid removed from each cell
+func (g RangeGrid) Subtract(other RangeGrid) RangeGrid {
+	for row := range g {
+		for col := range g[row] {
+			g[row][col] = math.Max(0, g[row][col]-other[row][col])
+		}
+	}
+	return g
+}
+
+// GridFromRange returns the grid of the weighted combos,
+// the weight of each cell is the average weight of the combos of its class, missing combos count as 0
+func GridFromRange(r WeightedRange) RangeGrid {
+	var g RangeGrid
+	for c, w := range r {
+		row, col := gridPosition(c.Class())
+		g[row][col] += w
+	}
+	for row := card.ACE; row >= card.TWO; row-- {
+		for col := card.ACE; col >= card.TWO; col-- {
+			g[gridIndex(row)][gridIndex(col)] /= float64(g.ClassAt(row, col).Combos())
+		}
+	}
+	return g
+}
+
+// ParseRangeGrid parses a range string, as described by ParseRange, into a grid
+func ParseRangeGrid(s string) (RangeGrid, error) {
+	r, err := ParseRange(s)
+	if err != nil {
+		return RangeGrid{}, err
+	}
+	return GridFromRange(r), nil
+}
+
+// PreflopRanking orders all 169 starting hand classes from strongest to weakest
+type PreflopRanking []PokerRange
+
+// EquityRanking orders the starting hand classes by their all-in equity against a single random hand
+var EquityRanking = parseRanking(`
+	AA KK QQ JJ TT 99 88 AKs AQs 77 AKo AJs ATs AQo AJo KQs 66 A9s ATo KJs A8s KTs KQo A7s A9o KJo QJs 55 K9s A8o
+	A6s A5s KTo QTs A4s A7o K8s QJo A3s Q9s K9o A6o A5o K7s JTs A2s QTo 44 A4o K6s Q8s K8o A3o K5s J9s JTo Q9o K7o A2o
+	K4s Q7s K6o K3s T9s J8s 33 Q6s Q8o K5o K2s J9o Q5s K4o T8s J7s Q4s Q7o T9o K3o J8o Q3s Q6o 98s K2o T7s J6s 22 Q2s
+	Q5o J5s J7o T8o 97s Q4o J4s T6s Q3o J3s 98o 87s J6o T7o 96s J2s Q2o J5o T5s T4s 97o J4o 86s T6o T3s 95s J3o 76s
+	87o T2s 85s J2o 96o T5o 94s 75s T4o 93s 86o 65s 84s 95o T3o 92s 76o 74s T2o 54s 85o 64s 83s 94o 75o 82s 93o 73s
+	65o 53s 63s 84o 92o 43s 74o 72s 54o 64o 52s 62s 83o 42s 82o 73o 53o 63o 32s 43o 72o 52o 62o 42o 32o`)
+
+// ChenRanking orders the starting hand classes by their Chen formula score,
+// classes with the same score keep their order from EquityRanking
+var ChenRanking = func() PreflopRanking {
+	ranking := append(PreflopRanking{}, EquityRanking...)
+	sort.SliceStable(ranking, func(i, j int) bool { return ChenScore(ranking[i]) > ChenScore(ranking[j]) })
+	return ranking
+}()
+
+// parseRanking parses a whitespace separated list of classes, it panics on invalid classes
+func parseRanking(s string) PreflopRanking {
+	var ranking PreflopRanking
+	for _, token := range strings.Fields(s) {
+		rc, err := parseRangeClass(token)
+		if err != nil {
+			panic(err)
+		}
+		ranking = append(ranking, rc.classes()...)
+	}
+	return ranking
+}
+
+// ChenScore returns the Chen formula score of the class
+func ChenScore(r PokerRange) float64 {
+	hi, lo := r.F0, r.F1
+	if hi < lo {
+		hi, lo = lo, hi
+	}
+
+	var score float64
+	switch hi {
+	case card.ACE:
+		score = 10
+	case card.KING:
+		score = 8
+	case card.QUEEN:
+		score = 7
+	case card.JACK:
+		score = 6
+	default:
+		score = float64(hi) / 2
+	}
+
+	if hi == lo {
+		return math.Max(5, score*2)
+	}
+
+	if r.Suited {
+		score += 2
+	}
+
+	gap := int(hi-lo) - 1
+	switch {
+	case gap == 1:
+		score--
+	case gap == 2:
+		score -= 2
+	case gap == 3:
+		score -= 4
+	case gap >= 4:
+		score -= 5
+	}
+
+	if gap <= 1 && hi < card.QUEEN {
+		score++
+	}
+
+	return math.Ceil(score)
+}
+
+// TopRange returns the strongest percentage of all combos according to the ranking, pct is in [0, 100].
+// Classes are added whole in order of the ranking, and the last class is given a partial weight
+// so that the grid holds exactly the requested share of combos.
+func TopRange(pct float64, ranking PreflopRanking) RangeGrid {
+	var g RangeGrid
+	remaining := math.Max(0, math.Min(100, pct)) / 100 * totalCombos
+	for _, class := range ranking {
+		if remaining <= 0 {
+			break
+		}
+		n := float64(class.Combos())
+		g.SetWeight(class, remaining/n)
+		remaining -= n
+	}
+	return g
+}
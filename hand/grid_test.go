@@ -0,0 +1,141 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeGridPositions(t *testing.T) {
+	var g RangeGrid
+	assert.Equal(t, NewPairRange(card.ACE), g.ClassAt(card.ACE, card.ACE))
+	assert.Equal(t, NewSuitedRange(card.ACE, card.KING), g.ClassAt(card.ACE, card.KING))
+	assert.Equal(t, NewOffsuitRange(card.KING, card.ACE), g.ClassAt(card.KING, card.ACE))
+
+	g.SetWeight(NewSuitedRange(card.ACE, card.KING), 0.5)
+	g.SetWeight(NewOffsuitRange(card.SEVEN, card.TWO), 2)
+	assert.Equal(t, 0.5, g.Cell(card.ACE, card.KING), "suited hands are above the diagonal")
+	assert.Equal(t, 0.5, g[0][1])
+	assert.Equal(t, 0.0, g.Cell(card.KING, card.ACE))
+	assert.Equal(t, 1.0, g.Cell(card.TWO, card.SEVEN), "offsuit hands are below the diagonal and weights are clamped")
+	assert.Equal(t, 1.0, g.Weight(NewOffsuitRange(card.TWO, card.SEVEN)))
+	assert.Equal(t, 0.5*4+12, g.Combos())
+}
+
+func TestRangeGridConversion(t *testing.T) {
+	tcs := []struct {
+		s      string
+		combos float64
+	}{
+		{"", 0},
+		{"22+", 78},
+		{"22+, A2s+, KTo+, Q9s+", 78 + 48 + 36 + 12},
+		{"AA, KK:0.5", 6 + 3},
+		{"AK", 16},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.s, func(tt *testing.T) {
+			g, err := ParseRangeGrid(tc.s)
+			if !assert.NoError(tt, err) {
+				return
+			}
+			assert.InDelta(tt, tc.combos, g.Combos(), 1e-9)
+
+			r, _ := ParseRange(tc.s)
+			assert.Equal(tt, r, g.Range())
+			assert.Equal(tt, r.String(), g.String())
+			assert.Equal(tt, g, GridFromRange(g.Range()))
+		})
+	}
+
+	// a class that is only partly held is spread over the whole class
+	g, err := ParseRangeGrid("AhKh, AsKs")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0.5, g.Weight(NewSuitedRange(card.ACE, card.KING)))
+		assert.Equal(t, "AKs:0.5", g.String())
+	}
+
+	_, err = ParseRangeGrid("AKx")
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
+
+func TestRangeGridSetOperations(t *testing.T) {
+	a, _ := ParseRangeGrid("QQ+, AK, KQs:0.5")
+	b, _ := ParseRangeGrid("JJ+:0.5, AKs, KQs")
+
+	union := a.Union(b)
+	assert.Equal(t, 1.0, union.Weight(NewPairRange(card.ACE)))
+	assert.Equal(t, 0.5, union.Weight(NewPairRange(card.JACK)))
+	assert.Equal(t, 1.0, union.Weight(NewSuitedRange(card.KING, card.QUEEN)))
+
+	intersect := a.Intersect(b)
+	assert.Equal(t, "QQ+:0.5,AKs,KQs:0.5", intersect.String())
+
+	subtract := a.Subtract(b)
+	assert.Equal(t, "QQ+:0.5,AKo", subtract.String())
+
+	aa, _ := ParseRangeGrid("QQ+, AK, KQs:0.5")
+	assert.Equal(t, aa, a, "set operations should not change the grid")
+}
+
+func TestChenScore(t *testing.T) {
+	tcs := []struct {
+		r     PokerRange
+		score float64
+	}{
+		{NewPairRange(card.ACE), 20},
+		{NewPairRange(card.TEN), 10},
+		{NewPairRange(card.TWO), 5},
+		{NewSuitedRange(card.ACE, card.KING), 12},
+		{NewSuitedRange(card.JACK, card.TEN), 9},
+		{NewOffsuitRange(card.KING, card.QUEEN), 8},
+		{NewSuitedRange(card.FIVE, card.SEVEN), 6},
+		{NewOffsuitRange(card.SEVEN, card.TWO), -1},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.r.String(), func(tt *testing.T) {
+			assert.Equal(tt, tc.score, ChenScore(tc.r))
+		})
+	}
+}
+
+func TestPreflopRankings(t *testing.T) {
+	for name, ranking := range map[string]PreflopRanking{"equity": EquityRanking, "chen": ChenRanking} {
+		t.Run(name, func(tt *testing.T) {
+			assert.Len(tt, ranking, 169)
+			seen := map[PokerRange]bool{}
+			combos := 0
+			for _, r := range ranking {
+				seen[r] = true
+				combos += r.Combos()
+			}
+			assert.Len(tt, seen, 169, "every class should be ranked exactly once")
+			assert.Equal(tt, totalCombos, combos)
+			assert.Equal(tt, NewPairRange(card.ACE), ranking[0])
+		})
+	}
+	assert.Equal(t, NewOffsuitRange(card.THREE, card.TWO), EquityRanking[168])
+}
+
+func TestTopRange(t *testing.T) {
+	assert.Equal(t, RangeGrid{}, TopRange(0, EquityRanking))
+	assert.InDelta(t, totalCombos, TopRange(100, EquityRanking).Combos(), 1e-9)
+	assert.InDelta(t, totalCombos, TopRange(150, ChenRanking).Combos(), 1e-9)
+
+	for _, pct := range []float64{0.5, 5, 10, 15.5, 33} {
+		g := TopRange(pct, EquityRanking)
+		assert.InDelta(t, pct/100*totalCombos, g.Combos(), 1e-9)
+	}
+
+	g := TopRange(1, EquityRanking)
+	assert.Equal(t, 1.0, g.Weight(NewPairRange(card.ACE)))
+	assert.Equal(t, 1.0, g.Weight(NewPairRange(card.KING)))
+	assert.InDelta(t, (13.26-12)/6, g.Weight(NewPairRange(card.QUEEN)), 1e-9, "the last class should be partly held")
+	assert.Equal(t, 0.0, g.Weight(NewPairRange(card.JACK)))
+
+	g = TopRange(100*(6+6+6+6+6)/float64(totalCombos), EquityRanking)
+	assert.Equal(t, "TT+", g.String())
+}
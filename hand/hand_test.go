@@ -20,9 +20,8 @@ func TestHandParsing(t *testing.T) {
 			name:  "high card",
 			shand: "2d5s6sjhac",
 			hand: Hand{
-				Hand:    HIGH_CARD,
-				Kicker0: card.ACE,
-				Kicker1: card.JACK,
+				Hand: HIGH_CARD,
+				Rank: newHandRank(HIGH_CARD, card.ACE, card.JACK, card.SIX, card.FIVE, card.TWO),
 				Contents: []card.Card{
 					card.ParsePokerCardString("2d"),
 					card.ParsePokerCardString("5s"),
@@ -36,9 +35,8 @@ func TestHandParsing(t *testing.T) {
 			name:  "pair",
 			shand: "2c5h5sjcad",
 			hand: Hand{
-				Hand:    PAIR,
-				Kicker0: card.FIVE,
-				Kicker1: card.ACE,
+				Hand: PAIR,
+				Rank: newHandRank(PAIR, card.FIVE, card.ACE, card.JACK, card.TWO),
 				Contents: []card.Card{
 					card.ParsePokerCardString("2c"),
 					card.ParsePokerCardString("5h"),
@@ -52,9 +50,8 @@ func TestHandParsing(t *testing.T) {
 			name:  "pair highest card",
 			shand: "2c3h5sacad",
 			hand: Hand{
-				Hand:    PAIR,
-				Kicker0: card.ACE,
-				Kicker1: card.FIVE,
+				Hand: PAIR,
+				Rank: newHandRank(PAIR, card.ACE, card.FIVE, card.THREE, card.TWO),
 				Contents: []card.Card{
 					card.ParsePokerCardString("2c"),
 					card.ParsePokerCardString("3h"),
@@ -68,9 +65,8 @@ func TestHandParsing(t *testing.T) {
 			name:  "flush",
 			shand: "2c4c7ctcqc",
 			hand: Hand{
-				Hand:    FLUSH,
-				Kicker0: card.QUEEN,
-				Kicker1: card.TEN,
+				Hand: FLUSH,
+				Rank: newHandRank(FLUSH, card.QUEEN, card.TEN, card.SEVEN, card.FOUR, card.TWO),
 				Contents: []card.Card{
 					card.ParsePokerCardString("2c"),
 					card.ParsePokerCardString("4c"),
@@ -243,6 +239,42 @@ func TestLessThan(t *testing.T) {
 			h2:   "2d5s7sjhkc",
 			h1:   "2s6s7sjhkc",
 		},
+		{
+			name: "high card less top kicker",
+			h1:   "3d5s6sjhkc",
+			h2:   "2s5s7sjhkc",
+			h1lt: true,
+		},
+		{
+			name: "two pair less fifth kicker",
+			h1:   "9c9dkhks2c",
+			h2:   "9h9skckd3c",
+			h1lt: true,
+		},
+		{
+			name: "two pair equal",
+			h1:   "9c9dkhks2c",
+			h2:   "9h9skckd2d",
+			eq:   true,
+		},
+		{
+			name: "two pair less second pair",
+			h1:   "2c2dkhksac",
+			h2:   "3c3dkckdqc",
+			h1lt: true,
+		},
+		{
+			name: "full house less pair",
+			h1:   "8h8d8ckhks",
+			h2:   "8s8c8dahac",
+			h1lt: true,
+		},
+		{
+			name: "quads less kicker",
+			h1:   "4d4h4c4s2c",
+			h2:   "4d4h4c4s3c",
+			h1lt: true,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -252,12 +284,15 @@ func TestLessThan(t *testing.T) {
 			if tc.h1lt {
 				assert.True(tt, h1.LessThan(h2), "h1 should be less than h2")
 				assert.False(tt, h1.Equals(h2), "h1 should not be equal to h2")
+				assert.Equal(tt, -1, h1.Compare(h2), "h1 should compare less than h2")
 			} else if tc.eq {
 				assert.True(tt, h1.Equals(h2), "h1 should be equal to h2")
 				assert.False(tt, h1.LessThan(h2), "h1 should not be less than h2")
+				assert.Equal(tt, 0, h1.Compare(h2), "h1 should compare equal to h2")
 			} else {
 				assert.False(tt, h1.LessThan(h2), "h1 should not be less than h2")
 				assert.False(tt, h1.Equals(h2), "h1 should not be equal to h2")
+				assert.Equal(tt, 1, h1.Compare(h2), "h1 should compare greater than h2")
 			}
 		})
 	}
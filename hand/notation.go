@@ -0,0 +1,341 @@
+package hand
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aaron-jencks/poker/card"
+)
+
+// ErrInvalidRange is returned when a range string can't be parsed
+var ErrInvalidRange = errors.New("invalid range")
+
+// WeightedRange is a set of hole card combos, mapped to the fraction of the time each combo is held,
+// weights are in the range (0, 1] and combos that are never held are left out
+type WeightedRange map[Combo]float64
+
+// faceNotation returns the character used for the face in range notation
+func faceNotation(f card.CardFace) byte {
+	return "23456789TJQKA"[f-card.TWO]
+}
+
+// parseFaceNotation returns the face for a range notation character, ignoring case
+func parseFaceNotation(b byte) (card.CardFace, bool) {
+	fi := strings.IndexByte("23456789TJQKAtjqka", b)
+	if fi < 0 {
+		return 0, false
+	}
+	return card.CardFace(fi%13) + card.TWO, true
+}
+
+// rangeClass is a starting hand class as written in a range string,
+// a class without a suffix stands for both the suited and offsuit hands
+type rangeClass struct {
+	f0, f1 card.CardFace
+	suffix byte // 's', 'o', or 0
+}
+
+// parseRangeClass parses a class such as QQ, AKs, KTo, or AK
+func parseRangeClass(s string) (rangeClass, error) {
+	if len(s) < 2 || len(s) > 3 {
+		return rangeClass{}, fmt.Errorf("%w: %q is not a hand", ErrInvalidRange, s)
+	}
+
+	f0, ok0 := parseFaceNotation(s[0])
+	f1, ok1 := parseFaceNotation(s[1])
+	if !ok0 || !ok1 {
+		return rangeClass{}, fmt.Errorf("%w: %q has an unknown face", ErrInvalidRange, s)
+	}
+	if f0 < f1 {
+		f0, f1 = f1, f0
+	}
+
+	rc := rangeClass{f0: f0, f1: f1}
+	if len(s) == 3 {
+		rc.suffix = s[2] | 0x20
+		if rc.suffix != 's' && rc.suffix != 'o' {
+			return rangeClass{}, fmt.Errorf("%w: %q has an unknown suffix", ErrInvalidRange, s)
+		}
+		if f0 == f1 {
+			return rangeClass{}, fmt.Errorf("%w: pair %q can't be suited or offsuit", ErrInvalidRange, s)
+		}
+	}
+	return rc, nil
+}
+
+// classes returns the starting hand classes that the range class stands for
+func (rc rangeClass) classes() []PokerRange {
+	if rc.f0 == rc.f1 {
+		return []PokerRange{{F0: rc.f0, F1: rc.f1}}
+	}
+
+	var result []PokerRange
+	if rc.suffix != 'o' {
+		result = append(result, PokerRange{F0: rc.f0, F1: rc.f1, Suited: true})
+	}
+	if rc.suffix != 's' {
+		result = append(result, PokerRange{F0: rc.f0, F1: rc.f1})
+	}
+	return result
+}
+
+// parseRangeHand parses a single range token without its weight, such as 22+, A2s+, QJs-Q9s, AKo, or AhKh
+func parseRangeHand(s string) ([]Combo, error) {
+	if cards, err := card.ParseCards(s); err == nil && len(cards) == 2 {
+		return []Combo{NewCombo(cards[0], cards[1])}, nil
+	}
+
+	var from, to rangeClass
+	var err error
+	if strings.HasSuffix(s, "+") {
+		if from, err = parseRangeClass(strings.TrimSuffix(s, "+")); err != nil {
+			return nil, err
+		}
+		to = from
+		if from.f0 == from.f1 {
+			to.f0, to.f1 = card.ACE, card.ACE
+		} else {
+			to.f1 = from.f0 - 1
+		}
+	} else if di := strings.IndexByte(s, '-'); di >= 0 {
+		if from, err = parseRangeClass(s[:di]); err != nil {
+			return nil, err
+		}
+		if to, err = parseRangeClass(s[di+1:]); err != nil {
+			return nil, err
+		}
+		if from.suffix != to.suffix || (from.f0 == from.f1) != (to.f0 == to.f1) || (from.f0 != from.f1 && from.f0 != to.f0) {
+			return nil, fmt.Errorf("%w: %q must span pairs, or hands with the same high card and suffix", ErrInvalidRange, s)
+		}
+		if from.f1 > to.f1 {
+			from, to = to, from
+		}
+	} else {
+		if from, err = parseRangeClass(s); err != nil {
+			return nil, err
+		}
+		to = from
+	}
+
+	var combos []Combo
+	for f := from.f1; f <= to.f1; f++ {
+		rc := from
+		rc.f1 = f
+		if from.f0 == from.f1 {
+			rc.f0 = f
+		}
+		for _, class := range rc.classes() {
+			combos = append(combos, classCombos(class)...)
+		}
+	}
+	return combos, nil
+}
+
+// ParseRange parses a range string such as "22+, A2s+, KTo+, QJs-Q9s, AhKh, AKs:0.5".
+// Tokens are separated by commas or whitespace, a token without a suffix includes both suited and offsuit hands,
+// and an optional weight after a colon sets the fraction of the time the combos are held.
+// When a combo appears in more than one token the last weight is used.
+func ParseRange(s string) (WeightedRange, error) {
+	result := WeightedRange{}
+	tokens := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r' })
+	for _, token := range tokens {
+		weight := 1.0
+		if ci := strings.IndexByte(token, ':'); ci >= 0 {
+			w, err := strconv.ParseFloat(token[ci+1:], 64)
+			if err != nil || w <= 0 || w > 1 {
+				return nil, fmt.Errorf("%w: weight of %q must be a number in (0, 1]", ErrInvalidRange, token)
+			}
+			weight = w
+			token = token[:ci]
+		}
+
+		combos, err := parseRangeHand(token)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range combos {
+			result[c] = weight
+		}
+	}
+	return result, nil
+}
+
+// classWeight returns the weight shared by every combo of the class,
+// or false if some combos are missing or have different weights
+func (r WeightedRange) classWeight(class PokerRange) (float64, bool) {
+	weight := 0.0
+	for ci, c := range classCombos(class) {
+		w, ok := r[c]
+		if !ok || (ci > 0 && w != weight) {
+			return 0, false
+		}
+		weight = w
+	}
+	return weight, true
+}
+
+// rangeToken is a single token of a formatted range and the classes that it covers
+type rangeToken struct {
+	text    string
+	classes []PokerRange
+}
+
+// kickerRuns merges consecutive kickers that share a weight into tokens, from the highest kicker down,
+// top is the highest kicker possible, which allows a run that reaches it to be written with a plus
+func kickerRuns(kickers []card.CardFace, weights map[card.CardFace]float64, top card.CardFace, name func(card.CardFace) string, classes func(card.CardFace) []PokerRange) []rangeToken {
+	var tokens []rangeToken
+	for ki := 0; ki < len(kickers); {
+		kj := ki + 1
+		for kj < len(kickers) && kickers[kj] == kickers[kj-1]-1 && weights[kickers[kj]] == weights[kickers[ki]] {
+			kj++
+		}
+
+		high, low := kickers[ki], kickers[kj-1]
+		token := rangeToken{}
+		for f := low; f <= high; f++ {
+			token.classes = append(token.classes, classes(f)...)
+		}
+		switch {
+		case high == low:
+			token.text = name(high)
+		case high == top:
+			token.text = name(low) + "+"
+		default:
+			token.text = name(high) + "-" + name(low)
+		}
+		token.text = weightNotation(token.text, weights[high])
+		tokens = append(tokens, token)
+		ki = kj
+	}
+	return tokens
+}
+
+// weightNotation appends the weight to a token unless it is 1
+func weightNotation(token string, weight float64) string {
+	if weight == 1 {
+		return token
+	}
+	return token + ":" + strconv.FormatFloat(weight, 'g', -1, 64)
+}
+
+// tokensLength returns the length of the tokens once they are joined
+func tokensLength(tokens []rangeToken) int {
+	n := 0
+	for _, t := range tokens {
+		n += len(t.text) + 1
+	}
+	return n
+}
+
+// String returns the range in the shortest canonical range notation, where whole classes are merged into
+// plus and dash ranges, classes that are only partly held are written as specific combos,
+// and weights other than 1 are written after a colon
+func (r WeightedRange) String() string {
+	var tokens []rangeToken
+
+	// pairs
+	var pairs []card.CardFace
+	pairWeights := map[card.CardFace]float64{}
+	for f := card.ACE; f >= card.TWO; f-- {
+		if w, ok := r.classWeight(PokerRange{F0: f, F1: f}); ok {
+			pairs = append(pairs, f)
+			pairWeights[f] = w
+		}
+	}
+	tokens = append(tokens, kickerRuns(pairs, pairWeights, card.ACE,
+		func(f card.CardFace) string { return string([]byte{faceNotation(f), faceNotation(f)}) },
+		func(f card.CardFace) []PokerRange { return []PokerRange{{F0: f, F1: f}} })...)
+
+	// non pairs, grouped by their high card
+	for f0 := card.ACE; f0 > card.TWO; f0-- {
+		name := func(suffix string) func(card.CardFace) string {
+			return func(f1 card.CardFace) string { return string([]byte{faceNotation(f0), faceNotation(f1)}) + suffix }
+		}
+		classes := func(s, o bool) func(card.CardFace) []PokerRange {
+			return func(f1 card.CardFace) []PokerRange {
+				var result []PokerRange
+				if s {
+					result = append(result, PokerRange{F0: f0, F1: f1, Suited: true})
+				}
+				if o {
+					result = append(result, PokerRange{F0: f0, F1: f1})
+				}
+				return result
+			}
+		}
+
+		var both, suited, offsuit, suitedOnly, offsuitOnly []card.CardFace
+		bothWeights := map[card.CardFace]float64{}
+		suitedWeights := map[card.CardFace]float64{}
+		offsuitWeights := map[card.CardFace]float64{}
+		for f1 := f0 - 1; f1 >= card.TWO; f1-- {
+			sw, sok := r.classWeight(PokerRange{F0: f0, F1: f1, Suited: true})
+			ow, ook := r.classWeight(PokerRange{F0: f0, F1: f1})
+			if sok {
+				suited = append(suited, f1)
+				suitedWeights[f1] = sw
+			}
+			if ook {
+				offsuit = append(offsuit, f1)
+				offsuitWeights[f1] = ow
+			}
+			if sok && ook && sw == ow {
+				both = append(both, f1)
+				bothWeights[f1] = sw
+				continue
+			}
+			if sok {
+				suitedOnly = append(suitedOnly, f1)
+			}
+			if ook {
+				offsuitOnly = append(offsuitOnly, f1)
+			}
+		}
+
+		// suited and offsuit hands can either be written separately, or with the hands they share merged,
+		// whichever is shorter is used
+		separate := kickerRuns(suited, suitedWeights, f0-1, name("s"), classes(true, false))
+		separate = append(separate, kickerRuns(offsuit, offsuitWeights, f0-1, name("o"), classes(false, true))...)
+		merged := kickerRuns(both, bothWeights, f0-1, name(""), classes(true, true))
+		merged = append(merged, kickerRuns(suitedOnly, suitedWeights, f0-1, name("s"), classes(true, false))...)
+		merged = append(merged, kickerRuns(offsuitOnly, offsuitWeights, f0-1, name("o"), classes(false, true))...)
+		if tokensLength(merged) < tokensLength(separate) {
+			tokens = append(tokens, merged...)
+		} else {
+			tokens = append(tokens, separate...)
+		}
+	}
+
+	written := map[Combo]bool{}
+	texts := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		texts = append(texts, t.text)
+		for _, class := range t.classes {
+			for _, c := range classCombos(class) {
+				written[c] = true
+			}
+		}
+	}
+
+	// everything else is written as specific combos
+	var rest []Combo
+	for c := range r {
+		if !written[c] {
+			rest = append(rest, c)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		if rest[i][0] != rest[j][0] {
+			return rest[i][0] > rest[j][0]
+		}
+		return rest[i][1] > rest[j][1]
+	})
+	for _, c := range rest {
+		texts = append(texts, weightNotation(c.String(), r[c]))
+	}
+
+	return strings.Join(texts, ",")
+}
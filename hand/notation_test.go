@@ -0,0 +1,146 @@
+package hand
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRange(t *testing.T) {
+	tcs := []struct {
+		s      string
+		combos int
+	}{
+		{"", 0},
+		{"AA", 6},
+		{"AKs", 4},
+		{"AKo", 12},
+		{"AK", 16},
+		{"ka", 16},
+		{"22+", 78},
+		{"A2s+", 48},
+		{"KTo+", 36},
+		{"QJs-Q9s", 12},
+		{"Q9s-QJs", 12},
+		{"TT-88", 18},
+		{"AhKh", 1},
+		{"AhKh, AKs", 4},
+		{"22+, A2s+, KTo+, QJs-Q9s", 78 + 48 + 36 + 12},
+		{"22+,22+", 78},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.s, func(tt *testing.T) {
+			r, err := ParseRange(tc.s)
+			assert.NoError(tt, err)
+			assert.Len(tt, r, tc.combos)
+		})
+	}
+}
+
+func TestParseRangeWeights(t *testing.T) {
+	r, err := ParseRange("AKs:0.5, AhKh, QQ+:0.25")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ah := card.CreateCard(card.ACE, card.HEARTS)
+	kh := card.CreateCard(card.KING, card.HEARTS)
+	as := card.CreateCard(card.ACE, card.SPADES)
+	ks := card.CreateCard(card.KING, card.SPADES)
+	assert.Equal(t, 1.0, r[NewCombo(kh, ah)], "the last weight of a combo should be used")
+	assert.Equal(t, 0.5, r[NewCombo(as, ks)])
+	assert.Equal(t, 0.25, r[NewCombo(as, ah)])
+	assert.Len(t, r, 4+18)
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	for _, s := range []string{"A", "AAs", "AKx", "ZZ", "AKs-QJs", "22-A2s", "AKs-AQo", "AKs:0", "AKs:1.5", "AKs:x", "AhAh", "AKQ+"} {
+		t.Run(s, func(tt *testing.T) {
+			_, err := ParseRange(s)
+			assert.ErrorIs(tt, err, ErrInvalidRange)
+		})
+	}
+}
+
+func TestFormatRange(t *testing.T) {
+	tcs := []struct {
+		s        string
+		expected string
+	}{
+		{"22+, A2s+, KTo+, QJs-Q9s", "22+,A2s+,KTo+,Q9s+"},
+		{"AKs, AKo", "AK"},
+		{"AhKh, AsKs, AdKd, AcKc", "AKs"},
+		{"QQ, JJ", "QQ-JJ"},
+		{"AA, KK:0.5", "AA,KK:0.5"},
+		{"88-TT, 44", "TT-88,44"},
+		{"A2s+, ATo+", "A2s+,ATo+"},
+		{"AK, AQ, AJs", "AQ+,AJs"},
+		{"JTs-J8s", "J8s+"},
+		{"T8s-T6s", "T8s-T6s"},
+		{"AhKh, QsQc:0.5", "AhKh,QsQc:0.5"},
+		{"KK, KhKs:0.5", "KsKh:0.5,KsKd,KsKc,KhKd,KhKc,KdKc"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.s, func(tt *testing.T) {
+			r, err := ParseRange(tc.s)
+			if !assert.NoError(tt, err) {
+				return
+			}
+			assert.Equal(tt, tc.expected, r.String())
+		})
+	}
+}
+
+func TestFormatRangeRoundTrip(t *testing.T) {
+	var combos []Combo
+	cards := standardCards()
+	for ci0 := range cards {
+		for ci1 := ci0 + 1; ci1 < len(cards); ci1++ {
+			combos = append(combos, NewCombo(cards[ci0], cards[ci1]))
+		}
+	}
+	assert.Len(t, combos, 1326)
+
+	rng := rand.New(rand.NewSource(4))
+	weights := []float64{1, 1, 1, 0.5, 0.25}
+	for i := 0; i < 200; i++ {
+		r := WeightedRange{}
+
+		// mostly whole classes with a few stray combos, like real ranges
+		for _, class := range []PokerRange{{F0: card.ACE, F1: card.ACE}, {F0: card.KING, F1: card.QUEEN, Suited: true}} {
+			if rng.Intn(2) == 0 {
+				for _, c := range classCombos(class) {
+					r[c] = 1
+				}
+			}
+		}
+		for f0 := card.TWO; f0 <= card.ACE; f0++ {
+			for f1 := card.TWO; f1 <= f0; f1++ {
+				for _, suited := range []bool{true, false} {
+					if (f0 == f1 && suited) || rng.Intn(3) != 0 {
+						continue
+					}
+					w := weights[rng.Intn(len(weights))]
+					for _, c := range classCombos(PokerRange{F0: f0, F1: f1, Suited: suited}) {
+						r[c] = w
+					}
+				}
+			}
+		}
+		for j := rng.Intn(10); j > 0; j-- {
+			r[combos[rng.Intn(len(combos))]] = weights[rng.Intn(len(weights))]
+		}
+
+		s := r.String()
+		parsed, err := ParseRange(s)
+		if !assert.NoError(t, err, s) {
+			return
+		}
+		assert.Equal(t, r, parsed, s)
+		assert.Equal(t, s, parsed.String(), "formatting should be canonical")
+	}
+}
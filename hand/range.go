@@ -2,31 +2,102 @@ package hand
 
 import "github.com/aaron-jencks/poker/card"
 
+// RangeClass represents the kind of starting hand that a range holds
+type RangeClass byte
+
+const (
+	POCKET_PAIR RangeClass = iota
+	SUITED
+	OFFSUIT
+)
+
+// PokerRange represents a starting hand class, such as 77, AKs, or KTo,
+// pairs have matching faces and ignore Suited
 type PokerRange struct {
 	F0     card.CardFace
 	F1     card.CardFace
 	Suited bool
 }
 
+// NewPairRange returns the range of the pocket pair with the given face
+func NewPairRange(f card.CardFace) PokerRange {
+	return PokerRange{F0: f, F1: f}
+}
+
+// NewSuitedRange returns the range of the suited hand with the given faces
+func NewSuitedRange(f0, f1 card.CardFace) PokerRange {
+	if f0 < f1 {
+		f0, f1 = f1, f0
+	}
+	return PokerRange{F0: f0, F1: f1, Suited: true}
+}
+
+// NewOffsuitRange returns the range of the offsuit hand with the given faces
+func NewOffsuitRange(f0, f1 card.CardFace) PokerRange {
+	if f0 < f1 {
+		f0, f1 = f1, f0
+	}
+	return PokerRange{F0: f0, F1: f1}
+}
+
+// Class returns whether the range holds a pocket pair, a suited hand, or an offsuit hand
+func (r PokerRange) Class() RangeClass {
+	if r.F0 == r.F1 {
+		return POCKET_PAIR
+	}
+	if r.Suited {
+		return SUITED
+	}
+	return OFFSUIT
+}
+
+// Combos returns the number of distinct combos in the range, 6 for pairs, 4 for suited hands, and 12 for offsuit hands
+func (r PokerRange) Combos() int {
+	switch r.Class() {
+	case POCKET_PAIR:
+		return 6
+	case SUITED:
+		return 4
+	}
+	return 12
+}
+
+// Pairs returns every distinct combo of the range, each with the higher card first
 func (r PokerRange) Pairs() [][]card.Card {
 	results := [][]card.Card{}
-	for s := card.CLUBS; s < card.SUITS; s++ {
-		c0 := card.CreateCard(r.F0, s)
-
-		if r.Suited {
-			c1 := card.CreateCard(r.F1, s)
-			results = append(results, []card.Card{c0, c1})
-			continue
-		}
+	for _, c := range classCombos(r) {
+		results = append(results, []card.Card{c[0], c[1]})
+	}
+	return results
+}
 
-		for s1 := card.CLUBS; s1 < card.SUITS; s1++ {
-			if s == s1 {
-				continue
+// Unblocked returns the combos of the range that don't contain any of the known cards
+func (r PokerRange) Unblocked(known []card.Card) [][]card.Card {
+	results := [][]card.Card{}
+combos:
+	for _, c := range classCombos(r) {
+		for _, kc := range known {
+			if c.Contains(kc) {
+				continue combos
 			}
-
-			c1 := card.CreateCard(r.F1, s1)
-			results = append(results, []card.Card{c0, c1})
 		}
+		results = append(results, []card.Card{c[0], c[1]})
 	}
 	return results
 }
+
+func (r PokerRange) String() string {
+	f0, f1 := r.F0, r.F1
+	if f0 < f1 {
+		f0, f1 = f1, f0
+	}
+
+	s := string([]byte{faceNotation(f0), faceNotation(f1)})
+	switch r.Class() {
+	case SUITED:
+		s += "s"
+	case OFFSUIT:
+		s += "o"
+	}
+	return s
+}
@@ -0,0 +1,60 @@
+package hand
+
+import (
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPokerRangeCombos(t *testing.T) {
+	tcs := []struct {
+		name   string
+		r      PokerRange
+		class  RangeClass
+		s      string
+		combos int
+	}{
+		{"pair", NewPairRange(card.SEVEN), POCKET_PAIR, "77", 6},
+		{"suited pair", PokerRange{F0: card.SEVEN, F1: card.SEVEN, Suited: true}, POCKET_PAIR, "77", 6},
+		{"suited", NewSuitedRange(card.KING, card.ACE), SUITED, "AKs", 4},
+		{"offsuit", NewOffsuitRange(card.TEN, card.KING), OFFSUIT, "KTo", 12},
+		{"reversed offsuit", PokerRange{F0: card.TWO, F1: card.SEVEN}, OFFSUIT, "72o", 12},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(tt *testing.T) {
+			assert.Equal(tt, tc.class, tc.r.Class())
+			assert.Equal(tt, tc.s, tc.r.String())
+			assert.Equal(tt, tc.combos, tc.r.Combos())
+
+			pairs := tc.r.Pairs()
+			assert.Len(tt, pairs, tc.combos)
+			seen := map[Combo]bool{}
+			for _, p := range pairs {
+				assert.Len(tt, p, 2)
+				assert.NotEqual(tt, p[0], p[1])
+				assert.True(tt, p[0] > p[1], "combos should hold the higher card first")
+				assert.Equal(tt, tc.class == SUITED, p[0].Suit() == p[1].Suit())
+				c := NewCombo(p[0], p[1])
+				assert.False(tt, seen[c], "combo %s is repeated", c)
+				seen[c] = true
+			}
+		})
+	}
+}
+
+func TestPokerRangeUnblocked(t *testing.T) {
+	aa := NewPairRange(card.ACE)
+	assert.Len(t, aa.Unblocked(nil), 6)
+	assert.Len(t, aa.Unblocked(card.ParseMultiPokerCardString("ah")), 3)
+	assert.Len(t, aa.Unblocked(card.ParseMultiPokerCardString("ahad")), 1)
+	assert.Len(t, aa.Unblocked(card.ParseMultiPokerCardString("ahadkc")), 1)
+
+	aks := NewSuitedRange(card.ACE, card.KING)
+	assert.Equal(t, [][]card.Card{card.ParseMultiPokerCardString("asks")}, aks.Unblocked(card.ParseMultiPokerCardString("ahkdac")))
+
+	ako := NewOffsuitRange(card.ACE, card.KING)
+	assert.Len(t, ako.Unblocked(card.ParseMultiPokerCardString("ah")), 9)
+	assert.Len(t, ako.Unblocked(card.ParseMultiPokerCardString("ahkh")), 6)
+}
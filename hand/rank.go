@@ -0,0 +1,53 @@
+package hand
+
+import "github.com/aaron-jencks/poker/card"
+
+// HandRank is a single comparable value for the strength of a hand,
+// a larger rank beats a smaller one and equal ranks split the pot.
+// The hand category is stored in bits 20-23 and the significant faces
+// are stored below it, one per nibble, most significant first.
+// cccc-ffff-ffff-ffff-ffff-ffff
+type HandRank uint32
+
+// newHandRank packs the category and the significant faces into a rank,
+// faces must be given in the order they are compared
+func newHandRank(category PokerHands, faces ...card.CardFace) HandRank {
+	r := HandRank(category) << 20
+	shift := 16
+	for _, f := range faces {
+		r |= HandRank(f) << shift
+		shift -= 4
+	}
+	return r
+}
+
+// Category returns the poker hand that this rank belongs to
+func (r HandRank) Category() PokerHands {
+	return PokerHands(r >> 20)
+}
+
+// Faces returns the significant faces of the rank in the order they are compared,
+// for example the trips face followed by the two kickers for three of a kind,
+// or only the highest face for a straight
+func (r HandRank) Faces() []card.CardFace {
+	faces := make([]card.CardFace, 0, 5)
+	for shift := 16; shift >= 0; shift -= 4 {
+		f := card.CardFace((r >> shift) & 0xf)
+		if f == 0 {
+			break
+		}
+		faces = append(faces, f)
+	}
+	return faces
+}
+
+// Compare returns -1 if this rank loses to the other, 1 if it beats the other, and 0 if they tie
+func (r HandRank) Compare(other HandRank) int {
+	if r < other {
+		return -1
+	}
+	if r > other {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,150 @@
+package hand
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/stretchr/testify/assert"
+)
+
+// referenceRank classifies five cards the slow way, by grouping the faces and checking
+// for flushes and straights directly, it returns the category and the faces in the order they are compared
+func referenceRank(cards []card.Card) (PokerHands, []card.CardFace) {
+	counts := map[card.CardFace]int{}
+	flush := true
+	for _, c := range cards {
+		counts[c.Face()]++
+		if c.Suit() != cards[0].Suit() {
+			flush = false
+		}
+	}
+
+	groups := make([]card.CardFace, 0, len(counts))
+	for f := range counts {
+		groups = append(groups, f)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if counts[groups[i]] != counts[groups[j]] {
+			return counts[groups[i]] > counts[groups[j]]
+		}
+		return groups[i] > groups[j]
+	})
+
+	straight := false
+	top := groups[0]
+	if len(groups) == 5 {
+		if groups[0]-groups[4] == 4 {
+			straight = true
+		} else if groups[0] == card.ACE && groups[1] == card.FIVE {
+			straight = true
+			top = card.FIVE
+		}
+	}
+
+	switch {
+	case straight && flush && top == card.ACE:
+		return ROYAL_FLUSH, []card.CardFace{top}
+	case straight && flush:
+		return STRAIGHT_FLUSH, []card.CardFace{top}
+	case counts[groups[0]] == 4:
+		return FOUR_OF_A_KIND, groups
+	case counts[groups[0]] == 3 && counts[groups[1]] == 2:
+		return FULL_HOUSE, groups
+	case flush:
+		return FLUSH, groups
+	case straight:
+		return STRAIGHT, []card.CardFace{top}
+	case counts[groups[0]] == 3:
+		return THREE_OF_A_KIND, groups
+	case counts[groups[0]] == 2 && counts[groups[1]] == 2:
+		return TWO_PAIR, groups
+	case counts[groups[0]] == 2:
+		return PAIR, groups
+	}
+	return HIGH_CARD, groups
+}
+
+// referenceLess compares two reference ranks by category and then by faces
+func referenceLess(c0 PokerHands, f0 []card.CardFace, c1 PokerHands, f1 []card.CardFace) bool {
+	if c0 != c1 {
+		return c0 < c1
+	}
+	for fi := range f0 {
+		if f0[fi] != f1[fi] {
+			return f0[fi] < f1[fi]
+		}
+	}
+	return false
+}
+
+func TestHandRankAllFiveCardHands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exhaustive hand rank test in short mode")
+	}
+
+	type reference struct {
+		category PokerHands
+		faces    []card.CardFace
+	}
+
+	cards := standardCards()
+	references := map[HandRank]reference{}
+	failures := 0
+	for ci0 := 0; ci0 < len(cards)-4; ci0++ {
+		for ci1 := ci0 + 1; ci1 < len(cards)-3; ci1++ {
+			for ci2 := ci1 + 1; ci2 < len(cards)-2; ci2++ {
+				for ci3 := ci2 + 1; ci3 < len(cards)-1; ci3++ {
+					for ci4 := ci3 + 1; ci4 < len(cards); ci4++ {
+						h := FindHand([]card.Card{cards[ci0], cards[ci1], cards[ci2], cards[ci3], cards[ci4]})
+						category, faces := referenceRank(h.Contents)
+						if _, ok := references[h.Rank]; !ok {
+							references[h.Rank] = reference{category, faces}
+						}
+
+						if h.Hand != category || h.Rank.Category() != category || !assert.ObjectsAreEqual(faces, h.Rank.Faces()) {
+							t.Errorf("hand %s: expected %d %v, found %d %v", h.String(), category, faces, h.Rank.Category(), h.Rank.Faces())
+							failures++
+							if failures > 10 {
+								t.FailNow()
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// the reference ordering of the distinct ranks must be strictly increasing
+	ranks := make([]HandRank, 0, len(references))
+	for r := range references {
+		ranks = append(ranks, r)
+	}
+	sort.Slice(ranks, func(i, j int) bool { return ranks[i] < ranks[j] })
+	for ri := 1; ri < len(ranks); ri++ {
+		prev, cur := references[ranks[ri-1]], references[ranks[ri]]
+		assert.True(t, referenceLess(prev.category, prev.faces, cur.category, cur.faces),
+			"expected %d %v to be less than %d %v", prev.category, prev.faces, cur.category, cur.faces)
+	}
+	assert.Equal(t, 7462, len(ranks), "expected the number of distinct hand ranks to match")
+}
+
+func TestHandRankFaces(t *testing.T) {
+	tcs := []struct {
+		name  string
+		shand string
+		faces []card.CardFace
+	}{
+		{"straight", "7c8h9dthjs", []card.CardFace{card.JACK}},
+		{"wheel", "ac2h3d4h5s", []card.CardFace{card.FIVE}},
+		{"full house", "8h8d8ckhks", []card.CardFace{card.EIGHT, card.KING}},
+		{"two pair", "9c9dkhks2c", []card.CardFace{card.KING, card.NINE, card.TWO}},
+		{"quads", "4dahadacas", []card.CardFace{card.ACE, card.FOUR}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(tt *testing.T) {
+			assert.Equal(tt, tc.faces, ParsePokerHandString(tc.shand).Rank.Faces())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package headsup
+
+import (
+	"sort"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/deck"
+	"github.com/aaron-jencks/poker/hand"
+)
+
+// Matchup is a pair of hole card combos, the equity of a matchup is the equity of the first combo
+type Matchup [2]hand.Combo
+
+// key packs the four cards of the matchup into 24 bits, six bits per card
+func (m Matchup) key() uint32 {
+	return uint32(m[0][0])<<18 | uint32(m[0][1])<<12 | uint32(m[1][0])<<6 | uint32(m[1][1])
+}
+
+// matchupFromKey unpacks a matchup packed by key
+func matchupFromKey(k uint32) Matchup {
+	c := func(shift uint) card.Card { return card.Card(k >> shift & 0x3f) }
+	return Matchup{{c(18), c(12)}, {c(6), c(0)}}
+}
+
+// canonicalSuits relabels the suits of the matchup so that isomorphic matchups share a single form.
+// Suits are sorted by the faces that the first combo holds in them, then by the faces of the second combo,
+// and are renamed clubs, diamonds, hearts, and spades in that order.
+func canonicalSuits(m Matchup) Matchup {
+	var masks [card.SUITS][2]uint16
+	for ci, c := range m {
+		for _, cc := range c {
+			masks[cc.Suit()][ci] |= 1 << cc.Face()
+		}
+	}
+
+	order := []card.CardSuit{card.CLUBS, card.DIAMONDS, card.HEARTS, card.SPADES}
+	sort.SliceStable(order, func(i, j int) bool {
+		mi, mj := masks[order[i]], masks[order[j]]
+		if mi[0] != mj[0] {
+			return mi[0] > mj[0]
+		}
+		return mi[1] > mj[1]
+	})
+	var perm [card.SUITS]card.CardSuit
+	for si, s := range order {
+		perm[s] = card.CardSuit(si)
+	}
+
+	relabel := func(c card.Card) card.Card { return card.CreateCard(c.Face(), perm[c.Suit()]) }
+	return Matchup{
+		hand.NewCombo(relabel(m[0][0]), relabel(m[0][1])),
+		hand.NewCombo(relabel(m[1][0]), relabel(m[1][1])),
+	}
+}
+
+// canonicalMatchup returns the canonical form of the matchup between the two combos,
+// and whether the combos were swapped to reach it. Swapping the combos doesn't change the matchup,
+// so only one of the two orders is stored.
+func canonicalMatchup(hero, villain hand.Combo) (Matchup, bool) {
+	m := canonicalSuits(Matchup{hero, villain})
+	swapped := canonicalSuits(Matchup{villain, hero})
+	if swapped.key() < m.key() {
+		return swapped, true
+	}
+	return m, false
+}
+
+// Matchups returns every canonical matchup, sorted by their packed keys
+func Matchups() []Matchup {
+	var combos []hand.Combo
+	cards := deck.StandardCards()
+	for ci0 := range cards {
+		for ci1 := ci0 + 1; ci1 < len(cards); ci1++ {
+			combos = append(combos, hand.NewCombo(cards[ci0], cards[ci1]))
+		}
+	}
+
+	seen := map[uint32]bool{}
+	var result []Matchup
+	for _, h := range combos {
+		for _, v := range combos {
+			if h.Contains(v[0]) || h.Contains(v[1]) {
+				continue
+			}
+			m, _ := canonicalMatchup(h, v)
+			if !seen[m.key()] {
+				seen[m.key()] = true
+				result = append(result, m)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].key() < result[j].key() })
+	return result
+}
@@ -0,0 +1,184 @@
+// Package headsup looks up the exact preflop all-in equity of one hand against another.
+//
+// The 1,624,350 ordered pairs of hole cards collapse into far fewer matchups once suits are relabeled
+// and the two hands are put in a fixed order, the table holds the exact result of every board for each of them.
+package headsup
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/hand"
+)
+
+//go:generate go run ../cmd/headsupgen -o equity.bin
+
+const (
+	// Version is the version of the table format that this package reads
+	Version = 1
+	// Boards is the number of boards that can be dealt to a heads-up matchup
+	Boards = 1712304
+	// recordSize is the number of bytes that each matchup takes up, three for the cards and three for the points
+	recordSize = 6
+)
+
+// magic starts every table file
+var magic = [4]byte{'P', 'K', 'H', 'U'}
+
+// errors returned when reading or querying a table
+var (
+	ErrVersion        = errors.New("unsupported heads-up table version")
+	ErrInvalidTable   = errors.New("invalid heads-up table")
+	ErrUnknownMatchup = errors.New("matchup is not in the heads-up table")
+	ErrDuplicateCard  = card.ErrDuplicateCard
+)
+
+//go:embed equity.bin
+var embeddedTable []byte
+
+// Table holds the points of canonical matchups, sorted by their packed keys.
+// A matchup scores two points for every board that the first hand wins and one for every board that is split.
+type Table struct {
+	keys   []uint32
+	points []uint32
+}
+
+// NewTable returns a table holding the given points of each canonical matchup
+func NewTable(points map[Matchup]uint32) *Table {
+	t := &Table{}
+	for m := range points {
+		t.keys = append(t.keys, m.key())
+	}
+	sort.Slice(t.keys, func(i, j int) bool { return t.keys[i] < t.keys[j] })
+	for _, k := range t.keys {
+		t.points = append(t.points, points[matchupFromKey(k)])
+	}
+	return t
+}
+
+// Len returns the number of matchups in the table
+func (t *Table) Len() int {
+	return len(t.keys)
+}
+
+// Decode reads a table in the format written by Encode
+func Decode(r io.Reader) (*Table, error) {
+	br := bufio.NewReader(r)
+	var header struct {
+		Magic   [4]byte
+		Version uint16
+		Count   uint32
+	}
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTable, err)
+	}
+	if header.Magic != magic {
+		return nil, fmt.Errorf("%w: bad magic %q", ErrInvalidTable, header.Magic[:])
+	}
+	if header.Version != Version {
+		return nil, fmt.Errorf("%w: found %d, expected %d", ErrVersion, header.Version, Version)
+	}
+
+	t := &Table{
+		keys:   make([]uint32, header.Count),
+		points: make([]uint32, header.Count),
+	}
+	var record [recordSize]byte
+	for i := range t.keys {
+		if _, err := io.ReadFull(br, record[:]); err != nil {
+			return nil, fmt.Errorf("%w: record %d: %v", ErrInvalidTable, i, err)
+		}
+		t.keys[i] = uint32(record[0]) | uint32(record[1])<<8 | uint32(record[2])<<16
+		t.points[i] = uint32(record[3]) | uint32(record[4])<<8 | uint32(record[5])<<16
+		if i > 0 && t.keys[i] <= t.keys[i-1] {
+			return nil, fmt.Errorf("%w: record %d is out of order", ErrInvalidTable, i)
+		}
+		if t.points[i] > 2*Boards {
+			return nil, fmt.Errorf("%w: record %d has %d points", ErrInvalidTable, i, t.points[i])
+		}
+	}
+	return t, nil
+}
+
+// Encode writes the table as a small header followed by one little endian record for each matchup
+func (t *Table) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	header := struct {
+		Magic   [4]byte
+		Version uint16
+		Count   uint32
+	}{magic, Version, uint32(len(t.keys))}
+	if err := binary.Write(bw, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	for i, k := range t.keys {
+		p := t.points[i]
+		if p > 2*Boards {
+			return fmt.Errorf("%w: %s has %d points", ErrInvalidTable, matchupFromKey(k), p)
+		}
+		record := [recordSize]byte{byte(k), byte(k >> 8), byte(k >> 16), byte(p), byte(p >> 8), byte(p >> 16)}
+		if _, err := bw.Write(record[:]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Points returns the points of the hero against the villain, out of 2*Boards
+func (t *Table) Points(hero, villain hand.Combo) (uint32, error) {
+	if hero.Contains(villain[0]) || hero.Contains(villain[1]) || hero[0] == hero[1] || villain[0] == villain[1] {
+		return 0, fmt.Errorf("%w: %s and %s", ErrDuplicateCard, hero, villain)
+	}
+	hero, villain = hand.NewCombo(hero[0], hero[1]), hand.NewCombo(villain[0], villain[1])
+
+	m, swapped := canonicalMatchup(hero, villain)
+	k := m.key()
+	i := sort.Search(len(t.keys), func(i int) bool { return t.keys[i] >= k })
+	if i == len(t.keys) || t.keys[i] != k {
+		return 0, fmt.Errorf("%w: %s and %s", ErrUnknownMatchup, hero, villain)
+	}
+	if swapped {
+		return 2*Boards - t.points[i], nil
+	}
+	return t.points[i], nil
+}
+
+// Equity returns the exact all-in equity of the hero against the villain
+func (t *Table) Equity(hero, villain hand.Combo) (float64, error) {
+	p, err := t.Points(hero, villain)
+	if err != nil {
+		return 0, err
+	}
+	return float64(p) / (2 * Boards), nil
+}
+
+var (
+	loadOnce    sync.Once
+	loadedTable *Table
+	loadErr     error
+)
+
+// Load returns the table that is embedded in the package, it is decoded on first use
+func Load() (*Table, error) {
+	loadOnce.Do(func() {
+		loadedTable, loadErr = Decode(bytes.NewReader(embeddedTable))
+	})
+	return loadedTable, loadErr
+}
+
+// Equity returns the exact all-in equity of the hero against the villain from the embedded table
+func Equity(hero, villain hand.Combo) (float64, error) {
+	t, err := Load()
+	if err != nil {
+		return 0, err
+	}
+	return t.Equity(hero, villain)
+}
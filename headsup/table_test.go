@@ -0,0 +1,144 @@
+package headsup
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/deck"
+	"github.com/aaron-jencks/poker/hand"
+	"github.com/aaron-jencks/poker/simulation"
+	"github.com/stretchr/testify/assert"
+)
+
+// combo parses a combo such as "ahkh"
+func combo(s string) hand.Combo {
+	cs := card.ParseMultiPokerCardString(s)
+	return hand.NewCombo(cs[0], cs[1])
+}
+
+// randomMatchup deals two random combos that don't share a card
+func randomMatchup(rng *rand.Rand) (hand.Combo, hand.Combo) {
+	cards := deck.StandardCards()
+	rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+	return hand.NewCombo(cards[0], cards[1]), hand.NewCombo(cards[2], cards[3])
+}
+
+func TestMatchups(t *testing.T) {
+	matchups := Matchups()
+	assert.Len(t, matchups, 47008)
+	for _, m := range matchups {
+		cm, _ := canonicalMatchup(m[0], m[1])
+		if !assert.Equal(t, m, cm, "matchups should already be canonical") {
+			return
+		}
+		assert.Equal(t, m, matchupFromKey(m.key()))
+	}
+}
+
+func TestCanonicalMatchup(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		hero, villain := randomMatchup(rng)
+		m, swapped := canonicalMatchup(hero, villain)
+		rm, rswapped := canonicalMatchup(villain, hero)
+		assert.Equal(t, m, rm, "the order of the combos shouldn't matter")
+		if swapped == rswapped {
+			assert.Equal(t, canonicalSuits(Matchup{hero, villain}), canonicalSuits(Matchup{villain, hero}),
+				"only symmetric matchups should keep both orders")
+		}
+
+		// relabeling the suits shouldn't change the matchup
+		perm := rng.Perm(int(card.SUITS))
+		relabel := func(c hand.Combo) hand.Combo {
+			return hand.NewCombo(
+				card.CreateCard(c[0].Face(), card.CardSuit(perm[c[0].Suit()])),
+				card.CreateCard(c[1].Face(), card.CardSuit(perm[c[1].Suit()])))
+		}
+		pm, _ := canonicalMatchup(relabel(hero), relabel(villain))
+		assert.Equal(t, m, pm, "%s %s", hero, villain)
+	}
+
+	a, _ := canonicalMatchup(combo("ahkh"), combo("qsqc"))
+	b, _ := canonicalMatchup(combo("adkd"), combo("qhqs"))
+	c, _ := canonicalMatchup(combo("ahkh"), combo("qhqc"))
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c, "sharing a suit with the villain is a different matchup")
+}
+
+func TestEncodeDecode(t *testing.T) {
+	m0, _ := canonicalMatchup(combo("ahkh"), combo("qsqc"))
+	m1, _ := canonicalMatchup(combo("2c7d"), combo("asad"))
+	table := NewTable(map[Matchup]uint32{m0: 1000, m1: 2 * Boards})
+	assert.Equal(t, 2, table.Len())
+
+	var buf bytes.Buffer
+	if !assert.NoError(t, table.Encode(&buf)) {
+		return
+	}
+	assert.Equal(t, 10+2*recordSize, buf.Len())
+
+	encoded := append([]byte{}, buf.Bytes()...)
+	decoded, err := Decode(&buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, table, decoded)
+
+	// the points are stored for the first combo of the canonical matchup
+	p, err := decoded.Points(m0[0], m0[1])
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1000), p)
+	p, err = decoded.Points(m0[1], m0[0])
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2*Boards-1000), p)
+	_, err = decoded.Points(combo("ahkh"), combo("2c2d"))
+	assert.ErrorIs(t, err, ErrUnknownMatchup)
+
+	bad := append([]byte{}, encoded...)
+	bad[0] = 'X'
+	_, err = Decode(bytes.NewReader(bad))
+	assert.ErrorIs(t, err, ErrInvalidTable)
+
+	bad = append([]byte{}, encoded...)
+	bad[4] = Version + 1
+	_, err = Decode(bytes.NewReader(bad))
+	assert.ErrorIs(t, err, ErrVersion)
+
+	_, err = Decode(bytes.NewReader(encoded[:len(encoded)-1]))
+	assert.ErrorIs(t, err, ErrInvalidTable, "a truncated table should be rejected")
+}
+
+func TestEmbeddedTable(t *testing.T) {
+	table, err := Load()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 47008, table.Len())
+
+	rng := rand.New(rand.NewSource(5))
+	for i := 0; i < 3; i++ {
+		hero, villain := randomMatchup(rng)
+		expected, err := simulation.EnumerateTableHand(2, map[int][]card.Card{0: hero[:], 1: villain[:]}, nil, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		equity, err := Equity(hero, villain)
+		assert.NoError(t, err)
+		assert.InDelta(t, expected[0].Equity, equity, 1e-9, "%s against %s", hero, villain)
+
+		equity, err = Equity(villain, hero)
+		assert.NoError(t, err)
+		assert.InDelta(t, expected[1].Equity, equity, 1e-9, "%s against %s", villain, hero)
+	}
+
+	// pocket aces against kings without shared suits
+	aa, err := Equity(combo("ahas"), combo("kckd"))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.8264, aa, 0.0001)
+
+	_, err = Equity(combo("ahas"), combo("ahkd"))
+	assert.ErrorIs(t, err, ErrDuplicateCard)
+}
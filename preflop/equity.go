@@ -0,0 +1,124 @@
+// Package preflop answers preflop all-in equity questions from tables that are generated ahead of time.
+package preflop
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/hand"
+)
+
+//go:generate go run ../cmd/preflopgen -o equity.json
+
+// Version is the version of the equity table format that this package reads
+const Version = 1
+
+// MaxOpponents is the largest number of random opponents in the equity table
+const MaxOpponents = 9
+
+// errors returned when reading or querying an equity table
+var (
+	ErrVersion      = errors.New("unsupported equity table version")
+	ErrInvalidTable = errors.New("invalid equity table")
+	ErrOpponents    = fmt.Errorf("number of opponents must be between 1 and %d", MaxOpponents)
+)
+
+//go:embed equity.json
+var embeddedTable []byte
+
+// ClassEquity holds the equity of a starting hand class against 1 to MaxOpponents random hands
+type ClassEquity struct {
+	Class  string    `json:"class"`  // the class in range notation, such as AKs
+	Equity []float64 `json:"equity"` // the equity against i+1 opponents is at index i
+}
+
+// EquityTable holds the all-in preflop equity of every starting hand class against random opponents
+type EquityTable struct {
+	Version      int           `json:"version"`
+	Seed         int64         `json:"seed"`           // the seed that the simulations were run with
+	Iterations   int           `json:"iterations"`     // the number of hands simulated for each class and number of opponents
+	ExactHeadsUp bool          `json:"exact_heads_up"` // the equities against a single opponent were enumerated exactly
+	Classes      []ClassEquity `json:"classes"`
+
+	index map[string][]float64
+}
+
+// Decode reads an equity table from JSON and validates it
+func Decode(r io.Reader) (*EquityTable, error) {
+	var t EquityTable
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTable, err)
+	}
+	if t.Version != Version {
+		return nil, fmt.Errorf("%w: found %d, expected %d", ErrVersion, t.Version, Version)
+	}
+
+	t.index = make(map[string][]float64, len(t.Classes))
+	for _, ce := range t.Classes {
+		if len(ce.Equity) != MaxOpponents {
+			return nil, fmt.Errorf("%w: %s has %d equities", ErrInvalidTable, ce.Class, len(ce.Equity))
+		}
+		t.index[ce.Class] = ce.Equity
+	}
+	for _, class := range hand.EquityRanking {
+		if t.index[class.String()] == nil {
+			return nil, fmt.Errorf("%w: %s is missing", ErrInvalidTable, class)
+		}
+	}
+	if len(t.index) != len(hand.EquityRanking) {
+		return nil, fmt.Errorf("%w: found %d classes", ErrInvalidTable, len(t.index))
+	}
+	return &t, nil
+}
+
+// Encode writes the equity table as JSON
+func (t *EquityTable) Encode(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(t)
+}
+
+// Equity returns the all-in equity of the class against the given number of random opponents
+func (t *EquityTable) Equity(class hand.PokerRange, opponents int) (float64, error) {
+	if opponents < 1 || opponents > MaxOpponents {
+		return 0, fmt.Errorf("%w: found %d", ErrOpponents, opponents)
+	}
+	return t.index[class.String()][opponents-1], nil
+}
+
+var (
+	loadOnce    sync.Once
+	loadedTable *EquityTable
+	loadErr     error
+)
+
+// Table returns the equity table that is embedded in the package, it is decoded on first use
+func Table() (*EquityTable, error) {
+	loadOnce.Do(func() {
+		loadedTable, loadErr = Decode(bytes.NewReader(embeddedTable))
+	})
+	return loadedTable, loadErr
+}
+
+// Equity returns the all-in equity of the class against the given number of random opponents
+func Equity(class hand.PokerRange, opponents int) (float64, error) {
+	t, err := Table()
+	if err != nil {
+		return 0, err
+	}
+	return t.Equity(class, opponents)
+}
+
+// HandEquity returns the all-in equity of the hole cards against the given number of random opponents
+func HandEquity(c0, c1 card.Card, opponents int) (float64, error) {
+	if c0 == c1 {
+		return 0, fmt.Errorf("%w: %s", card.ErrDuplicateCard, c0)
+	}
+	return Equity(hand.NewCombo(c0, c1).Class(), opponents)
+}
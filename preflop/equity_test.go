@@ -0,0 +1,80 @@
+package preflop
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/hand"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedTable(t *testing.T) {
+	table, err := Table()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, Version, table.Version)
+	assert.Len(t, table.Classes, 169)
+
+	for _, ce := range table.Classes {
+		for i := 1; i < len(ce.Equity); i++ {
+			assert.Less(t, ce.Equity[i], ce.Equity[i-1], "%s should lose equity against more opponents", ce.Class)
+		}
+	}
+
+	aa, err := Equity(hand.NewPairRange(card.ACE), 1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.852, aa, 0.005)
+
+	aks, err := HandEquity(card.ParsePokerCardString("kh"), card.ParsePokerCardString("ah"), 1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.670, aks, 0.005)
+
+	offsuit, err := Equity(hand.NewOffsuitRange(card.TWO, card.SEVEN), 1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.346, offsuit, 0.005)
+
+	// the equities against several opponents fall somewhere below an equal share
+	for opponents := 2; opponents <= MaxOpponents; opponents++ {
+		e, err := Equity(hand.NewOffsuitRange(card.TWO, card.SEVEN), opponents)
+		assert.NoError(t, err)
+		assert.Less(t, e, 1/float64(opponents+1))
+	}
+}
+
+func TestEquityErrors(t *testing.T) {
+	_, err := Equity(hand.NewPairRange(card.ACE), 0)
+	assert.ErrorIs(t, err, ErrOpponents)
+	_, err = Equity(hand.NewPairRange(card.ACE), MaxOpponents+1)
+	assert.ErrorIs(t, err, ErrOpponents)
+
+	ah := card.ParsePokerCardString("ah")
+	_, err = HandEquity(ah, ah, 1)
+	assert.ErrorIs(t, err, card.ErrDuplicateCard)
+}
+
+func TestDecode(t *testing.T) {
+	table, err := Table()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var buf bytes.Buffer
+	if !assert.NoError(t, table.Encode(&buf)) {
+		return
+	}
+	decoded, err := Decode(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, table, decoded, "tables should round trip")
+
+	_, err = Decode(strings.NewReader(`{"version": 2}`))
+	assert.ErrorIs(t, err, ErrVersion)
+	_, err = Decode(strings.NewReader(`{"version": 1, "classes": [{"class": "AA", "equity": [0.85]}]}`))
+	assert.ErrorIs(t, err, ErrInvalidTable)
+	_, err = Decode(strings.NewReader(`{"version": 1, "classes": []}`))
+	assert.ErrorIs(t, err, ErrInvalidTable)
+	_, err = Decode(strings.NewReader(`not json`))
+	assert.ErrorIs(t, err, ErrInvalidTable)
+}
@@ -0,0 +1,162 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/deck"
+	"github.com/aaron-jencks/poker/hand"
+)
+
+// errors returned when a table cannot be dealt
+var (
+	ErrMissingHand   = errors.New("seat has no hole cards")
+	ErrDuplicateCard = deck.ErrDuplicateCard
+	ErrBoardSize     = errors.New("board must have 0, 3, 4 or 5 cards")
+)
+
+// SeatEquity holds the showdown results of a single seat as fractions of all showdowns
+type SeatEquity struct {
+	Win    float64 // the seat won the whole pot
+	Tie    float64 // the seat split the pot with other seats
+	Loss   float64 // the seat won nothing
+	Equity float64 // the average share of the pot won by the seat
+}
+
+// checkBoard returns an error if the board isn't empty, a flop, a turn, or a river
+func checkBoard(board []card.Card) error {
+	if len(board) > 5 || len(board) == 1 || len(board) == 2 {
+		return fmt.Errorf("%w: found %d", ErrBoardSize, len(board))
+	}
+	return nil
+}
+
+// usedCards returns the set of cards held by the seats and the board, folded seats may have no hole cards,
+// it returns an error if a seat is missing its hole cards or if a card is used twice
+func usedCards(nplayers int, fixed_hands map[int][]card.Card, board []card.Card, folds map[int]bool) (map[card.Card]bool, error) {
+	if err := checkBoard(board); err != nil {
+		return nil, err
+	}
+
+	used := map[card.Card]bool{}
+	use := func(c card.Card) error {
+		if used[c] {
+			return fmt.Errorf("%w: %s", ErrDuplicateCard, c)
+		}
+		used[c] = true
+		return nil
+	}
+
+	for _, c := range board {
+		if err := use(c); err != nil {
+			return nil, err
+		}
+	}
+
+	for seat := 0; seat < nplayers; seat++ {
+		if !folds[seat] && len(fixed_hands[seat]) != 2 {
+			return nil, fmt.Errorf("%w: seat %d", ErrMissingHand, seat)
+		}
+		for _, c := range fixed_hands[seat] {
+			if err := use(c); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return used, nil
+}
+
+// EnumerateTableHand computes the exact equity of every seat by dealing every possible runout of the board,
+// every seat that has not folded must have fixed hole cards, and the board may be empty or hold the flop, turn, or river.
+// The returned slice is indexed by seat, folded seats are left empty.
+func EnumerateTableHand(nplayers int, fixed_hands map[int][]card.Card, board []card.Card, folds map[int]bool) ([]SeatEquity, error) {
+	used, err := usedCards(nplayers, fixed_hands, board, folds)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make([]card.Card, 0, 52)
+	for s := card.CLUBS; s < card.SUITS; s++ {
+		for f := card.TWO; f < card.JOKER; f++ {
+			if c := card.CreateCard(f, s); !used[c] {
+				remaining = append(remaining, c)
+			}
+		}
+	}
+
+	// every seat keeps its own 7 card buffer, the runout is written into the tail of it
+	var seats []int
+	seatCards := map[int][]card.Card{}
+	for seat := 0; seat < nplayers; seat++ {
+		if folds[seat] {
+			continue
+		}
+		seats = append(seats, seat)
+		cards := make([]card.Card, 0, 7)
+		cards = append(cards, fixed_hands[seat]...)
+		cards = append(cards, board...)
+		seatCards[seat] = cards[:7]
+	}
+
+	wins := make([]int, nplayers)
+	ties := make([]int, nplayers)
+	shares := make([]float64, nplayers)
+	ranks := make([]hand.HandRank, nplayers)
+	total := 0
+
+	missing := 5 - len(board)
+	runout := make([]int, missing)
+	var deal func(depth, start int)
+	deal = func(depth, start int) {
+		if depth < missing {
+			for ri := start; ri <= len(remaining)-(missing-depth); ri++ {
+				runout[depth] = ri
+				deal(depth+1, ri+1)
+			}
+			return
+		}
+
+		var best hand.HandRank
+		winners := 0
+		for _, seat := range seats {
+			cards := seatCards[seat]
+			for ri, ci := range runout {
+				cards[7-missing+ri] = remaining[ci]
+			}
+			ranks[seat] = hand.Evaluate(cards)
+			if winners == 0 || ranks[seat] > best {
+				best = ranks[seat]
+				winners = 1
+			} else if ranks[seat] == best {
+				winners++
+			}
+		}
+
+		for _, seat := range seats {
+			if ranks[seat] != best {
+				continue
+			}
+			if winners == 1 {
+				wins[seat]++
+			} else {
+				ties[seat]++
+			}
+			shares[seat] += 1 / float64(winners)
+		}
+		total++
+	}
+	deal(0, 0)
+
+	result := make([]SeatEquity, nplayers)
+	for _, seat := range seats {
+		result[seat] = SeatEquity{
+			Win:    float64(wins[seat]) / float64(total),
+			Tie:    float64(ties[seat]) / float64(total),
+			Loss:   float64(total-wins[seat]-ties[seat]) / float64(total),
+			Equity: shares[seat] / float64(total),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumerateTableHand(t *testing.T) {
+	tcs := []struct {
+		name   string
+		hands  []string
+		board  string
+		folds  map[int]bool
+		equity []SeatEquity
+	}{
+		{
+			name:  "river",
+			hands: []string{"ahad", "kckd"},
+			board: "2c7d9hjs3c",
+			equity: []SeatEquity{
+				{Win: 1, Equity: 1},
+				{Loss: 1},
+			},
+		},
+		{
+			name:  "turn two outs",
+			hands: []string{"ahad", "kckd"},
+			board: "2c7d9hjs",
+			equity: []SeatEquity{
+				{Win: 42.0 / 44, Loss: 2.0 / 44, Equity: 42.0 / 44},
+				{Win: 2.0 / 44, Loss: 42.0 / 44, Equity: 2.0 / 44},
+			},
+		},
+		{
+			name:  "board plays",
+			hands: []string{"2c3d", "4c5d", "2d3c"},
+			board: "ahkhqhjhth",
+			equity: []SeatEquity{
+				{Tie: 1, Equity: 1.0 / 3},
+				{Tie: 1, Equity: 1.0 / 3},
+				{Tie: 1, Equity: 1.0 / 3},
+			},
+		},
+		{
+			name:  "folded seat",
+			hands: []string{"ahad", "kckd", "ksqh"},
+			board: "2c7d9hjs",
+			folds: map[int]bool{2: true},
+			equity: []SeatEquity{
+				{Win: 41.0 / 42, Loss: 1.0 / 42, Equity: 41.0 / 42},
+				{Win: 1.0 / 42, Loss: 41.0 / 42, Equity: 1.0 / 42},
+				{},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(tt *testing.T) {
+			hands := map[int][]card.Card{}
+			for hi, h := range tc.hands {
+				hands[hi] = card.ParseMultiPokerCardString(h)
+			}
+
+			equity, err := EnumerateTableHand(len(tc.hands), hands, card.ParseMultiPokerCardString(tc.board), tc.folds)
+			if !assert.NoError(tt, err) || !assert.Len(tt, equity, len(tc.equity)) {
+				return
+			}
+			for seat := range tc.equity {
+				assert.InDelta(tt, tc.equity[seat].Win, equity[seat].Win, 1e-9, "seat %d win", seat)
+				assert.InDelta(tt, tc.equity[seat].Tie, equity[seat].Tie, 1e-9, "seat %d tie", seat)
+				assert.InDelta(tt, tc.equity[seat].Loss, equity[seat].Loss, 1e-9, "seat %d loss", seat)
+				assert.InDelta(tt, tc.equity[seat].Equity, equity[seat].Equity, 1e-9, "seat %d equity", seat)
+			}
+		})
+	}
+}
+
+func TestEnumerateTableHandErrors(t *testing.T) {
+	hands := map[int][]card.Card{
+		0: card.ParseMultiPokerCardString("ahad"),
+		1: card.ParseMultiPokerCardString("kckd"),
+	}
+
+	_, err := EnumerateTableHand(3, hands, nil, nil)
+	assert.ErrorIs(t, err, ErrMissingHand)
+
+	_, err = EnumerateTableHand(2, hands, card.ParseMultiPokerCardString("ah2c3c"), nil)
+	assert.ErrorIs(t, err, ErrDuplicateCard)
+
+	_, err = EnumerateTableHand(2, hands, card.ParseMultiPokerCardString("2c3c4c5c6c7c"), nil)
+	assert.ErrorIs(t, err, ErrBoardSize)
+}
+
+func TestEnumerateMatchesSimulation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping preflop enumeration in short mode")
+	}
+
+	hands := map[int][]card.Card{
+		0: card.ParseMultiPokerCardString("ahks"),
+		1: card.ParseMultiPokerCardString("qcqd"),
+	}
+
+	equity, err := EnumerateTableHand(2, hands, nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	const iterations = 20000
+	wins := make([]float64, 2)
+	for i := 0; i < iterations; i++ {
+		winners, err := SimulateTableHand(2, hands, nil, nil, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		for _, seat := range winners {
+			wins[seat] += 1 / float64(len(winners))
+		}
+	}
+
+	for seat := range wins {
+		assert.InDelta(t, equity[seat].Equity, wins[seat]/iterations, 0.015, "seat %d", seat)
+	}
+	assert.InDelta(t, 1, equity[0].Equity+equity[1].Equity, 1e-9)
+}
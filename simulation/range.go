@@ -1,9 +1,82 @@
 package simulation
 
 import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/aaron-jencks/poker/card"
 	"github.com/aaron-jencks/poker/hand"
 )
 
-func SimulateTableRangeHand(nplayers int, r hand.PokerRange, folds map[int]bool) [][]int {
-	return nil
+// errors returned when ranges cannot be dealt
+var (
+	ErrEmptyRange    = errors.New("range has no combos that are not blocked")
+	ErrRangeConflict = errors.New("ranges block each other")
+	ErrSeatConflict  = errors.New("seat has both fixed hole cards and a range")
+)
+
+// knownCards returns the other known cards, such as the board and dead cards, followed by the fixed hole cards
+func knownCards(fixed_hands map[int][]card.Card, known ...[]card.Card) []card.Card {
+	var cards []card.Card
+	for _, kc := range known {
+		cards = append(cards, kc...)
+	}
+
+	seats := make([]int, 0, len(fixed_hands))
+	for seat := range fixed_hands {
+		seats = append(seats, seat)
+	}
+	sort.Ints(seats) // reports the same duplicate card every time
+	for _, seat := range seats {
+		cards = append(cards, fixed_hands[seat]...)
+	}
+	return cards
+}
+
+// rangeCombos returns the distinct combos of the ranges that don't contain any blocked cards
+func rangeCombos(ranges []hand.PokerRange, blocked map[card.Card]bool) [][]card.Card {
+	seen := map[hand.Combo]bool{}
+	var combos [][]card.Card
+	for _, r := range ranges {
+		for _, combo := range r.Pairs() {
+			if blocked[combo[0]] || blocked[combo[1]] {
+				continue
+			}
+
+			key := hand.NewCombo(combo[0], combo[1])
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			combos = append(combos, combo)
+		}
+	}
+	return combos
+}
+
+// SimulateTableRangeHand simulates a number of hands where the seat holds a random combo from the range,
+// combos blocked by the fixed hole cards or the dead cards are never dealt and every other seat gets random cards.
+// The returned slice holds the win and split counts of every seat.
+func SimulateTableRangeHand(nplayers int, seat int, r hand.PokerRange, fixed_hands map[int][]card.Card, dead []card.Card, folds map[int]bool, iterations int) ([]SeatResult, error) {
+	return SimulateTableMultiRangeHand(nplayers, map[int][]hand.PokerRange{seat: {r}}, fixed_hands, dead, folds, iterations)
+}
+
+// SimulateTableMultiRangeHand simulates a number of hands where every seat in ranges holds a random combo
+// from the union of its ranges, seats without a range or fixed hole cards get random cards.
+// Each deal is drawn uniformly from the combinations of combos that don't share any cards.
+// The returned slice holds the win and split counts of every seat.
+func SimulateTableMultiRangeHand(nplayers int, ranges map[int][]hand.PokerRange, fixed_hands map[int][]card.Card, dead []card.Card, folds map[int]bool, iterations int) ([]SeatResult, error) {
+	runner := Runner{
+		Seed:       rand.Int63(),
+		Workers:    1,
+		Iterations: iterations,
+	}
+	return runner.Run(Table{
+		Players: nplayers,
+		Hands:   fixed_hands,
+		Ranges:  ranges,
+		Dead:    dead,
+		Folds:   folds,
+	})
 }
@@ -0,0 +1,76 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/hand"
+	"github.com/stretchr/testify/assert"
+)
+
+// pairsAndUp returns the pocket pair ranges from the given face up to aces, e.g. 22+
+func pairsAndUp(f card.CardFace) []hand.PokerRange {
+	var ranges []hand.PokerRange
+	for ; f <= card.ACE; f++ {
+		ranges = append(ranges, hand.PokerRange{F0: f, F1: f})
+	}
+	return ranges
+}
+
+func TestSimulateTableRangeHand(t *testing.T) {
+	const iterations = 5000
+	aks := hand.PokerRange{F0: card.ACE, F1: card.KING, Suited: true}
+	results, err := SimulateTableRangeHand(2, 0, aks, nil, nil, nil, iterations)
+	if !assert.NoError(t, err) || !assert.Len(t, results, 2) {
+		return
+	}
+
+	// AKs has roughly 67% equity against a random hand
+	equity := (float64(results[0].Wins) + float64(results[0].Splits)/2) / iterations
+	assert.InDelta(t, 0.67, equity, 0.03)
+	assert.Equal(t, results[0].Splits, results[1].Splits, "both seats share every split pot")
+	assert.LessOrEqual(t, results[0].Wins+results[1].Wins+results[0].Splits, iterations)
+}
+
+func TestSimulateTableMultiRangeHand(t *testing.T) {
+	const iterations = 5000
+	ranges := map[int][]hand.PokerRange{
+		0: {{F0: card.ACE, F1: card.KING, Suited: true}},
+		1: pairsAndUp(card.TWO),
+	}
+	results, err := SimulateTableMultiRangeHand(2, ranges, nil, nil, nil, iterations)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// AKs is close to a coin flip against 22+
+	equity := (float64(results[0].Wins) + float64(results[0].Splits)/2) / iterations
+	assert.InDelta(t, 0.48, equity, 0.04)
+}
+
+func TestSimulateTableRangeHandBlocked(t *testing.T) {
+	aa := hand.PokerRange{F0: card.ACE, F1: card.ACE}
+	kk := hand.PokerRange{F0: card.KING, F1: card.KING}
+
+	// only AsAh remains
+	dead := card.ParseMultiPokerCardString("acad")
+	fixed := map[int][]card.Card{1: card.ParseMultiPokerCardString("kskh")}
+	results, err := SimulateTableRangeHand(2, 0, aa, fixed, dead, nil, 1000)
+	if assert.NoError(t, err) {
+		// only the last two kings, quads, or a straight flush can beat aces
+		assert.Greater(t, results[0].Wins, 750)
+	}
+
+	_, err = SimulateTableRangeHand(2, 0, aa, nil, card.ParseMultiPokerCardString("acadah"), nil, 10)
+	assert.ErrorIs(t, err, ErrEmptyRange)
+
+	_, err = SimulateTableRangeHand(2, 0, aa, fixed, card.ParseMultiPokerCardString("ks"), nil, 10)
+	assert.ErrorIs(t, err, ErrDuplicateCard)
+
+	_, err = SimulateTableRangeHand(2, 1, kk, fixed, nil, nil, 10)
+	assert.ErrorIs(t, err, ErrSeatConflict)
+
+	ranges := map[int][]hand.PokerRange{0: {aa}, 1: {aa}}
+	_, err = SimulateTableMultiRangeHand(2, ranges, nil, dead, nil, 10)
+	assert.ErrorIs(t, err, ErrRangeConflict)
+}
@@ -0,0 +1,244 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/deck"
+	"github.com/aaron-jencks/poker/hand"
+)
+
+const (
+	// streamSize is the number of hands simulated from each random stream,
+	// the streams are independent of the number of workers which keeps runs repeatable
+	streamSize = 1024
+	// maxRangeAttempts is the number of times a deal of the ranges is retried
+	// before the ranges are considered to block each other completely
+	maxRangeAttempts = 1000
+)
+
+// Table describes the hand that is being simulated
+type Table struct {
+	Players int                       // the number of seats at the table
+	Hands   map[int][]card.Card       // the fixed hole cards of each seat
+	Ranges  map[int][]hand.PokerRange // the ranges that each seat without fixed hole cards is dealt from
+	Board   []card.Card               // the known board cards, only the missing streets are dealt
+	Dead    []card.Card               // cards that are out of play
+	Folds   map[int]bool              // seats that have folded and don't take part in the showdown
+}
+
+// tableSetup holds everything about a table that is shared between the workers of a run
+type tableSetup struct {
+	Table
+	seats  []int                 // the seats dealt from a range, in ascending order
+	combos map[int][][]card.Card // the combos of each ranged seat that aren't blocked
+	base   []card.Card           // the cards left after removing fixed hole cards, the board, and dead cards
+}
+
+// newTableSetup validates the table and prepares it for dealing
+func newTableSetup(t Table) (*tableSetup, error) {
+	if err := checkBoard(t.Board); err != nil {
+		return nil, err
+	}
+	known := knownCards(t.Hands, t.Board, t.Dead)
+	remaining := deck.CreateStackedDeck(deck.StandardCards())
+	if err := remaining.RemoveCards(known...); err != nil {
+		return nil, err
+	}
+	blocked := make(map[card.Card]bool, len(known))
+	for _, c := range known {
+		blocked[c] = true
+	}
+
+	setup := &tableSetup{
+		Table:  t,
+		combos: map[int][][]card.Card{},
+		base:   remaining.Cards,
+	}
+	for seat, sr := range t.Ranges {
+		if t.Folds[seat] {
+			continue
+		}
+		if t.Hands[seat] != nil {
+			return nil, fmt.Errorf("%w: seat %d", ErrSeatConflict, seat)
+		}
+		combos := rangeCombos(sr, blocked)
+		if len(combos) == 0 {
+			return nil, fmt.Errorf("%w: seat %d", ErrEmptyRange, seat)
+		}
+		setup.seats = append(setup.seats, seat)
+		setup.combos[seat] = combos
+	}
+	sort.Ints(setup.seats) // keeps the order of the draws independent of map iteration
+
+	return setup, nil
+}
+
+// dealer deals random showdowns of a table, it holds the state of a single worker and must not be shared
+type dealer struct {
+	*tableSetup
+	deck     deck.Deck
+	buf      []card.Card
+	used     []card.Card
+	hcardMap map[int][]card.Card
+}
+
+func (s *tableSetup) newDealer() *dealer {
+	return &dealer{
+		tableSetup: s,
+		buf:        make([]card.Card, 0, len(s.base)),
+		used:       make([]card.Card, 0, 2*len(s.seats)),
+		hcardMap:   map[int][]card.Card{},
+	}
+}
+
+// isUsed returns true if the card was already dealt from a range
+func (d *dealer) isUsed(c card.Card) bool {
+	for _, uc := range d.used {
+		if uc == c {
+			return true
+		}
+	}
+	return false
+}
+
+// dealRanges picks a combo for every ranged seat, retrying whenever two combos share a card
+func (d *dealer) dealRanges(rng *rand.Rand) error {
+	for attempt := 0; attempt < maxRangeAttempts; attempt++ {
+		d.used = d.used[:0]
+		dealt := true
+		for _, seat := range d.seats {
+			combo := d.combos[seat][rng.Intn(len(d.combos[seat]))]
+			if d.isUsed(combo[0]) || d.isUsed(combo[1]) {
+				dealt = false
+				break
+			}
+			d.used = append(d.used, combo...)
+			d.hcardMap[seat] = combo
+		}
+		if dealt {
+			return nil
+		}
+	}
+	return ErrRangeConflict
+}
+
+// deal deals a single random showdown and returns the winners
+func (d *dealer) deal(rng *rand.Rand) ([]int, error) {
+	for seat := range d.hcardMap {
+		delete(d.hcardMap, seat)
+	}
+	for seat, hcards := range d.Hands {
+		d.hcardMap[seat] = hcards
+	}
+
+	if err := d.dealRanges(rng); err != nil {
+		return nil, err
+	}
+
+	d.deck.Cards = d.buf[:0]
+	for _, c := range d.base {
+		if !d.isUsed(c) {
+			d.deck.Cards = append(d.deck.Cards, c)
+		}
+	}
+	d.deck.ShuffleWith(rng)
+
+	return dealShowdown(d.Players, d.hcardMap, d.Board, &d.deck, d.Folds), nil
+}
+
+// run deals n showdowns and tallies the results of every seat
+func (d *dealer) run(rng *rand.Rand, n int) ([]SeatResult, error) {
+	results := make([]SeatResult, d.Players)
+	for i := 0; i < n; i++ {
+		winners, err := d.deal(rng)
+		if err != nil {
+			return nil, err
+		}
+		for _, seat := range winners {
+			if len(winners) == 1 {
+				results[seat].Wins++
+			} else {
+				results[seat].Splits++
+			}
+			results[seat].Shares += 1 / float64(len(winners))
+		}
+	}
+	return results, nil
+}
+
+// streamSeed derives the seed of a random stream from the seed of the run using splitmix64
+func streamSeed(seed int64, stream int) int64 {
+	z := uint64(seed) + uint64(stream+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
+
+// Runner simulates many hands of a table in parallel.
+// The iterations are split into fixed size streams that each get their own random source,
+// so a given seed always produces the same results no matter how many workers are used.
+type Runner struct {
+	Seed       int64 // the seed that every random stream is derived from
+	Workers    int   // the number of goroutines to simulate with, all cpus are used if this is less than 1
+	Iterations int   // the number of hands to simulate
+}
+
+// Run simulates the table and returns the merged win and split counts of every seat
+func (r Runner) Run(t Table) ([]SeatResult, error) {
+	setup, err := newTableSetup(t)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := r.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	streams := (r.Iterations + streamSize - 1) / streamSize
+	streamResults := make([][]SeatResult, streams)
+	streamErrors := make([]error, streams)
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d := setup.newDealer()
+			for {
+				stream := int(next.Add(1) - 1)
+				if stream >= streams {
+					return
+				}
+
+				n := streamSize
+				if stream == streams-1 {
+					n = r.Iterations - stream*streamSize
+				}
+				rng := rand.New(rand.NewSource(streamSeed(r.Seed, stream)))
+				streamResults[stream], streamErrors[stream] = d.run(rng, n)
+			}
+		}()
+	}
+	wg.Wait()
+
+	results := make([]SeatResult, t.Players)
+	for stream := range streamResults {
+		if streamErrors[stream] != nil {
+			return nil, streamErrors[stream]
+		}
+		for seat, sr := range streamResults[stream] {
+			results[seat].Wins += sr.Wins
+			results[seat].Splits += sr.Splits
+			results[seat].Shares += sr.Shares
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,93 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/deck"
+	"github.com/aaron-jencks/poker/hand"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunnerDeterministic(t *testing.T) {
+	table := Table{
+		Players: 3,
+		Hands:   map[int][]card.Card{0: card.ParseMultiPokerCardString("ahkh")},
+		Ranges:  map[int][]hand.PokerRange{1: pairsAndUp(card.TEN)},
+	}
+
+	// not a multiple of the stream size, so the last stream is short
+	const iterations = 5000
+	expected, err := Runner{Seed: 42, Workers: 1, Iterations: iterations}.Run(table)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	total := 0
+	for _, sr := range expected {
+		total += sr.Wins
+	}
+	assert.LessOrEqual(t, total, iterations)
+	assert.Greater(t, total, iterations*9/10, "split pots should be rare")
+
+	for _, workers := range []int{2, 3, 8} {
+		results, err := Runner{Seed: 42, Workers: workers, Iterations: iterations}.Run(table)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, results, "expected %d workers to match a single worker", workers)
+	}
+
+	results, err := Runner{Seed: 43, Workers: 1, Iterations: iterations}.Run(table)
+	assert.NoError(t, err)
+	assert.NotEqual(t, expected, results, "expected a different seed to give different results")
+}
+
+func TestRunnerErrors(t *testing.T) {
+	aa := hand.PokerRange{F0: card.ACE, F1: card.ACE}
+	table := Table{
+		Players: 2,
+		Ranges:  map[int][]hand.PokerRange{0: {aa}, 1: {aa}},
+		Dead:    card.ParseMultiPokerCardString("acad"),
+	}
+	_, err := Runner{Seed: 1, Workers: 2, Iterations: 100}.Run(table)
+	assert.ErrorIs(t, err, ErrRangeConflict)
+
+	table.Dead = card.ParseMultiPokerCardString("acadah")
+	_, err = Runner{Seed: 1, Workers: 2, Iterations: 100}.Run(table)
+	assert.ErrorIs(t, err, ErrEmptyRange)
+
+	table.Dead = []card.Card{card.CreateCard(card.JOKER, card.SPADES)}
+	_, err = Runner{Seed: 1, Workers: 2, Iterations: 100}.Run(table)
+	assert.ErrorIs(t, err, deck.ErrCardNotInDeck)
+}
+
+func TestRunnerBoard(t *testing.T) {
+	hands := map[int][]card.Card{
+		0: card.ParseMultiPokerCardString("ahkh"),
+		1: card.ParseMultiPokerCardString("qcqd"),
+	}
+	board := card.ParseMultiPokerCardString("2h7hqs")
+
+	equity, err := EnumerateTableHand(2, hands, board, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	const iterations = 20000
+	results, err := Runner{Seed: 7, Workers: 2, Iterations: iterations}.Run(Table{
+		Players: 2,
+		Hands:   hands,
+		Board:   board,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for seat, sr := range results {
+		simulated := (float64(sr.Wins) + float64(sr.Splits)/2) / iterations
+		assert.InDelta(t, equity[seat].Equity, simulated, 0.015, "seat %d", seat)
+		assert.InDelta(t, simulated, sr.Equity(iterations), 1e-9, "seat %d", seat)
+	}
+
+	_, err = Runner{Seed: 7, Iterations: 10}.Run(Table{Players: 2, Hands: hands, Board: board[:2]})
+	assert.ErrorIs(t, err, ErrBoardSize)
+}
@@ -8,74 +8,123 @@ import (
 	"github.com/aaron-jencks/poker/hand"
 )
 
+// FindBestHand returns the best five card hand that can be made from the hole cards and the table,
+// every five card subset of the combined cards is considered, so hands that play one hole card,
+// or that play the board, are found as well. Between 5 and 7 cards should be supplied in total.
 func FindBestHand(hole []card.Card, table []card.Card) hand.Hand {
-	possibleHands := make([]hand.Hand, 10) // 5C3 is 10
-	for ti0 := 0; ti0 < len(table)-2; ti0++ {
-		for ti1 := ti0 + 1; ti1 < len(table)-1; ti1++ {
-			for ti2 := ti1 + 1; ti2 < len(table); ti2++ {
-				possibleHands = append(possibleHands, hand.FindHand([]card.Card{
-					hole[0],
-					hole[1],
-					table[ti0],
-					table[ti1],
-					table[ti2],
-				}))
+	cards := make([]card.Card, 0, len(hole)+len(table))
+	cards = append(cards, hole...)
+	cards = append(cards, table...)
+
+	var bh hand.Hand
+	first := true
+	for ci0 := 0; ci0 < len(cards)-4; ci0++ {
+		for ci1 := ci0 + 1; ci1 < len(cards)-3; ci1++ {
+			for ci2 := ci1 + 1; ci2 < len(cards)-2; ci2++ {
+				for ci3 := ci2 + 1; ci3 < len(cards)-1; ci3++ {
+					for ci4 := ci3 + 1; ci4 < len(cards); ci4++ {
+						// FindHand sorts the cards in place, so it needs its own copy
+						h := hand.FindHand([]card.Card{cards[ci0], cards[ci1], cards[ci2], cards[ci3], cards[ci4]})
+						if first || bh.Compare(h) < 0 {
+							bh = h
+							first = false
+						}
+					}
+				}
 			}
 		}
 	}
-	sort.Slice(possibleHands, func(i, j int) bool { return possibleHands[i].LessThan(possibleHands[j]) })
-	return possibleHands[len(possibleHands)-1]
+	return bh
 }
 
+// findTableWinner returns the seats holding the best hand, in ascending order
 func findTableWinner(handMap map[int]hand.Hand) []int {
-	// find the winners
+	rankMap := make(map[int]hand.HandRank, len(handMap))
+	for seat, h := range handMap {
+		rankMap[seat] = h.Rank
+	}
+	return findRankWinner(rankMap)
+}
+
+// findRankWinner returns the seats holding the highest rank, in ascending order
+func findRankWinner(rankMap map[int]hand.HandRank) []int {
 	var bestHands []int
-	var bh hand.Hand
+	var br hand.HandRank
 	first := true
-	for seat, h := range handMap {
-		if first || bh.LessThan(h) {
-			bh = h
+	for seat, r := range rankMap {
+		if first || br.Compare(r) < 0 {
+			br = r
 			bestHands = nil
-			if first {
-				first = false
-			}
+			first = false
 		}
-		if bh.Equals(h) {
+		if br == r {
 			bestHands = append(bestHands, seat)
 		}
 	}
+	sort.Ints(bestHands)
 	return bestHands
 }
 
-func SimulateTableHand(nplayers int, fixed_hands map[int][]card.Card, folds map[int]bool) []int {
-	deck := deck.CreateStandardDeck()
+// SeatResult holds the showdown tallies of a single seat over a number of simulated hands
+type SeatResult struct {
+	Wins   int     // hands won outright
+	Splits int     // hands where the pot was split with other seats
+	Shares float64 // the number of pots won, where a split pot counts as the fraction held by the seat
+}
 
-	hcardMap := map[int][]card.Card{}
-	for seat, hcards := range fixed_hands {
-		hcardMap[seat] = hcards
-		for _, c := range hcards {
-			deck.DrawCard(c)
-		}
+// Equity returns the average share of the pot won by the seat over the given number of hands
+func (r SeatResult) Equity(hands int) float64 {
+	if hands == 0 {
+		return 0
 	}
+	return r.Shares / float64(hands)
+}
 
+// dealShowdown deals hole cards from the deck to every seat that has none, deals the rest of the board,
+// and returns the winners of the showdown
+func dealShowdown(nplayers int, hcardMap map[int][]card.Card, board []card.Card, d *deck.Deck, folds map[int]bool) []int {
 	// deal cards
 	for si := 0; si < nplayers; si++ {
 		if hcardMap[si] != nil {
 			continue
 		}
-		hcardMap[si] = deck.Draw(2)
+		hcardMap[si] = d.Draw(2)
 	}
 
-	table := deck.Draw(5)
+	table := make([]card.Card, 0, 5)
+	table = append(table, board...)
+	table = append(table, d.Draw(5-len(board))...)
 
 	// find hands
-	handMap := map[int]hand.Hand{}
+	rankMap := map[int]hand.HandRank{}
+	cards := make([]card.Card, 0, 7)
 	for seat := 0; seat < nplayers; seat++ {
 		if folds[seat] {
 			continue
 		}
-		handMap[seat] = FindBestHand(hcardMap[seat], table)
+		cards = append(append(cards[:0], hcardMap[seat]...), table...)
+		rankMap[seat] = hand.Evaluate(cards)
+	}
+
+	return findRankWinner(rankMap)
+}
+
+// SimulateTableHand deals a single random hand and returns the winners of the showdown,
+// seats with fixed hole cards keep them, dead cards are never dealt, and only the streets missing from the board are dealt
+func SimulateTableHand(nplayers int, fixed_hands map[int][]card.Card, board []card.Card, dead []card.Card, folds map[int]bool) ([]int, error) {
+	if err := checkBoard(board); err != nil {
+		return nil, err
+	}
+
+	deck, err := deck.CreateStandardDeckWithout(knownCards(fixed_hands, board, dead))
+	if err != nil {
+		return nil, err
+	}
+
+	hcardMap := map[int][]card.Card{}
+	for seat, hcards := range fixed_hands {
+		hcardMap[seat] = hcards
 	}
 
-	return findTableWinner(handMap)
+	return dealShowdown(nplayers, hcardMap, board, &deck, folds), nil
 }
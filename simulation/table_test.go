@@ -6,34 +6,118 @@ import (
 	"testing"
 
 	"github.com/aaron-jencks/poker/card"
+	"github.com/aaron-jencks/poker/deck"
 	"github.com/aaron-jencks/poker/hand"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestFindBestHand(t *testing.T) {
 	tcs := []struct {
+		name  string
 		hand  string
 		table string
 		bh    hand.PokerHands
+		faces string // the faces of the best hand in ascending order, skipped when empty
 	}{
 		{
+			name:  "two pair",
 			hand:  "4h3d",
 			table: "2sts4c2hac",
 			bh:    hand.TWO_PAIR,
+			faces: "2244a",
 		},
 		{
+			name:  "high card",
 			hand:  "qc9d",
 			table: "6h5ckh8c3h",
 			bh:    hand.HIGH_CARD,
+			faces: "689qk",
+		},
+		{
+			name:  "one card flush",
+			hand:  "ah2c",
+			table: "kh9h5h3hjd",
+			bh:    hand.FLUSH,
+			faces: "359ka",
+		},
+		{
+			name:  "one card straight",
+			hand:  "9c2d",
+			table: "5h6s7d8ckh",
+			bh:    hand.STRAIGHT,
+			faces: "56789",
+		},
+		{
+			name:  "one card wheel",
+			hand:  "as9c",
+			table: "2c3d4hkd5s",
+			bh:    hand.STRAIGHT,
+			faces: "2345a",
+		},
+		{
+			name:  "one card kicker",
+			hand:  "ac7d",
+			table: "kskh9s9d3c",
+			bh:    hand.TWO_PAIR,
+			faces: "99kka",
+		},
+		{
+			name:  "one card quads",
+			hand:  "8s2c",
+			table: "8h8d8cah3s",
+			bh:    hand.FOUR_OF_A_KIND,
+			faces: "8888a",
+		},
+		{
+			name:  "board plays royal flush",
+			hand:  "2c3d",
+			table: "ahkhqhjhth",
+			bh:    hand.ROYAL_FLUSH,
+			faces: "tjqka",
+		},
+		{
+			name:  "board plays straight",
+			hand:  "2c3d",
+			table: "5h6s7d8c9h",
+			bh:    hand.STRAIGHT,
+			faces: "56789",
+		},
+		{
+			name:  "board plays full house",
+			hand:  "4c3d",
+			table: "jhjsjdkcks",
+			bh:    hand.FULL_HOUSE,
+			faces: "jjjkk",
+		},
+		{
+			name:  "turn",
+			hand:  "ahkh",
+			table: "qhjhth2c",
+			bh:    hand.ROYAL_FLUSH,
+			faces: "tjqka",
+		},
+		{
+			name:  "flop",
+			hand:  "7c7d",
+			table: "7h2s2d",
+			bh:    hand.FULL_HOUSE,
+			faces: "22777",
 		},
 	}
 
 	for _, tc := range tcs {
-		t.Run(fmt.Sprintf("%s %s", tc.hand, tc.table), func(tt *testing.T) {
+		t.Run(tc.name, func(tt *testing.T) {
 			h := card.ParseMultiPokerCardString(tc.hand)
 			tbl := card.ParseMultiPokerCardString(tc.table)
 			ah := FindBestHand(h, tbl)
 			assert.Equal(tt, tc.bh, ah.Hand, "expected parsed hands to be equal")
+			if tc.faces != "" {
+				faces := ""
+				for _, c := range ah.Contents {
+					faces += c.String()[:1]
+				}
+				assert.Equal(tt, tc.faces, faces, "expected best hand faces to be equal")
+			}
 		})
 	}
 }
@@ -52,6 +136,16 @@ func TestFindWinner(t *testing.T) {
 			table:  "6h5ckh8c3h",
 			winner: []int{6},
 		},
+		{
+			hs:     []string{"2c3d", "4c5d", "qc9d"},
+			table:  "ahkhqhjhth",
+			winner: []int{0, 1, 2},
+		},
+		{
+			hs:     []string{"ac2d", "as3d", "qcjd"},
+			table:  "9c9skhks4h",
+			winner: []int{0, 1},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -68,3 +162,57 @@ func TestFindWinner(t *testing.T) {
 		})
 	}
 }
+
+func TestSimulateTableHandBoard(t *testing.T) {
+	hands := map[int][]card.Card{
+		0: card.ParseMultiPokerCardString("ahad"),
+		1: card.ParseMultiPokerCardString("kckd"),
+	}
+
+	// only the river is dealt, and aces lose to the last two kings
+	losses := 0
+	for i := 0; i < 200; i++ {
+		winners, err := SimulateTableHand(2, hands, card.ParseMultiPokerCardString("2c7d9hjs"), nil, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, winners, 1)
+		if winners[0] == 1 {
+			losses++
+		}
+	}
+	assert.Less(t, losses, 40)
+
+	winners, err := SimulateTableHand(2, hands, card.ParseMultiPokerCardString("2c7d9hjsks"), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, winners, "the river is known")
+
+	_, err = SimulateTableHand(2, hands, card.ParseMultiPokerCardString("2c7d"), nil, nil)
+	assert.ErrorIs(t, err, ErrBoardSize)
+
+	_, err = SimulateTableHand(2, hands, card.ParseMultiPokerCardString("2c7dah"), nil, nil)
+	assert.ErrorIs(t, err, ErrDuplicateCard)
+}
+
+func TestSimulateTableHandDeadCards(t *testing.T) {
+	hands := map[int][]card.Card{
+		0: card.ParseMultiPokerCardString("ahad"),
+		1: card.ParseMultiPokerCardString("kckd"),
+	}
+	board := card.ParseMultiPokerCardString("2c7d9hjs")
+
+	// with both remaining kings dead the aces can't lose
+	for i := 0; i < 100; i++ {
+		winners, err := SimulateTableHand(2, hands, board, card.ParseMultiPokerCardString("khks"), nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []int{0}, winners)
+	}
+
+	_, err := SimulateTableHand(2, hands, board, card.ParseMultiPokerCardString("ah"), nil)
+	assert.ErrorIs(t, err, ErrDuplicateCard)
+
+	_, err = SimulateTableHand(2, hands, board, []card.Card{card.CreateCard(card.JOKER, card.SPADES)}, nil)
+	assert.ErrorIs(t, err, deck.ErrCardNotInDeck)
+}